@@ -0,0 +1,162 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "runtime"
+    "strings"
+    "unsafe"
+)
+
+// alterUnit checks the unit and returns its C name, conversion factor,
+// authority and code, the latter two being nil for units not coming from the
+// database. The returned function releases the C strings.
+//
+func alterUnit ( ctx *Context, op string, u *Unit, cat UnitCategory ) ( name *C.char, factor C.double, auth *C.char, code *C.char, release func (), e error ) {
+    if u == nil {
+        e = newError(ctx, op, "unit", ErrInvalidParameter, "Missing unit")
+        return
+    }
+    if name, factor, e = unitParameter(ctx, op, "unit", u, cat) ; e != nil {
+        return
+    }
+    if parts := strings.SplitN(u.ID(), ":", 2) ; len(parts) == 2 {
+        auth, code = C.CString(parts[0]), C.CString(parts[1])
+    }
+    release = func () {
+        C.free(unsafe.Pointer(name))
+        C.free(unsafe.Pointer(auth))
+        C.free(unsafe.Pointer(code))
+    }
+    return
+}
+
+// alter returns the reference system created by `f` from the reference
+// system.
+//
+func (crs *ReferenceSystem) alter ( ctx *Context, op string, f func () *C.PJ ) ( c *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    pj := f()
+    if pj == nil {
+        e = contextError(ctx, op, crs.String(), ErrInvalidDefinition)
+        return
+    }
+    c = newReferenceSystem(ctx, pj)
+    return
+}
+
+// WithName returns a copy of the reference system named `name`.
+//
+func (crs *ReferenceSystem) WithName ( ctx *Context, name string ) ( *ReferenceSystem, error ) {
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    return crs.alter(ctx, "WithName", func () *C.PJ {
+        return C.proj_alter_name((*ctx).pj, (*crs).pj, cname)
+    })
+}
+
+// WithIdentifier returns a copy of the reference system identified by
+// `authority` and `code`, replacing its former identifiers :
+//
+//   c, e := crs.WithIdentifier(ctx, "MYCOMPANY", "1001")
+//
+func (crs *ReferenceSystem) WithIdentifier ( ctx *Context, authority string, code string ) ( *ReferenceSystem, error ) {
+    if authority == "" || code == "" {
+        return nil, newError(ctx, "WithIdentifier", authority+":"+code, ErrInvalidParameter, "Missing authority or code")
+    }
+    cauth := C.CString(authority)
+    defer C.free(unsafe.Pointer(cauth))
+    ccode := C.CString(code)
+    defer C.free(unsafe.Pointer(ccode))
+    return crs.alter(ctx, "WithIdentifier", func () *C.PJ {
+        return C.proj_alter_id((*ctx).pj, (*crs).pj, cauth, ccode)
+    })
+}
+
+// WithGeodeticCRS returns a copy of the reference system whose geodetic
+// reference system is replaced by `base`. For a geographic or geocentric
+// reference system, the result is a new copy of `base`; for a projected
+// one, the projection is kept on top of `base`.
+//
+func (crs *ReferenceSystem) WithGeodeticCRS ( ctx *Context, base *ReferenceSystem ) ( *ReferenceSystem, error ) {
+    if base == nil {
+        return nil, newError(ctx, "WithGeodeticCRS", crs.String(), ErrInvalidParameter, "Missing geodetic CRS")
+    }
+    switch base.TypeOf() {
+    case Geographic2DCRS, Geographic3DCRS, GeocentricCRS :
+    default :
+        return nil, newError(ctx, "WithGeodeticCRS", crs.String(), ErrNotACRS, "Expected a geodetic CRS")
+    }
+    defer base.use.enter(base, (*base).ctx, ctx)()
+    defer runtime.KeepAlive(base)
+    return crs.alter(ctx, "WithGeodeticCRS", func () *C.PJ {
+        return C.proj_crs_alter_geodetic_crs((*ctx).pj, (*crs).pj, (*base).pj)
+    })
+}
+
+// WithAngularUnit returns a copy of the geographic reference system whose
+// axes are in the angular unit `u` :
+//
+//   grad, _ := GetAngularUnitByID("grad")
+//   c, e := crs.WithAngularUnit(ctx, grad)
+//
+func (crs *ReferenceSystem) WithAngularUnit ( ctx *Context, u *Unit ) ( *ReferenceSystem, error ) {
+    name, factor, auth, code, release, e := alterUnit(ctx, "WithAngularUnit", u, AngularUnit)
+    if e != nil {
+        return nil, e
+    }
+    defer release()
+    return crs.alter(ctx, "WithAngularUnit", func () *C.PJ {
+        return C.proj_crs_alter_cs_angular_unit((*ctx).pj, (*crs).pj, name, factor, auth, code)
+    })
+}
+
+// WithLinearUnit returns a copy of the projected, geocentric or vertical
+// reference system whose axes are in the linear unit `u`. The parameters of
+// the projection are left unchanged (see `WithParametersLinearUnit`), e.g.
+// EPSG:2154 in US survey feet :
+//
+//   ft, _ := ctx.UnitOfMeasure("EPSG", "9003")
+//   c, e := crs.WithLinearUnit(ctx, ft)
+//
+func (crs *ReferenceSystem) WithLinearUnit ( ctx *Context, u *Unit ) ( *ReferenceSystem, error ) {
+    name, factor, auth, code, release, e := alterUnit(ctx, "WithLinearUnit", u, LinearUnit)
+    if e != nil {
+        return nil, e
+    }
+    defer release()
+    return crs.alter(ctx, "WithLinearUnit", func () *C.PJ {
+        return C.proj_crs_alter_cs_linear_unit((*ctx).pj, (*crs).pj, name, factor, auth, code)
+    })
+}
+
+// WithParametersLinearUnit returns a copy of the projected reference system
+// whose linear parameters, e.g. false easting and northing, are expressed in
+// the linear unit `u`. When `convert` is true, the values are converted to
+// the new unit, leaving the projection unchanged; otherwise they are kept as
+// is, hence expressed in the new unit.
+//
+func (crs *ReferenceSystem) WithParametersLinearUnit ( ctx *Context, u *Unit, convert bool ) ( *ReferenceSystem, error ) {
+    if crs.TypeOf() != ProjectedCRS {
+        return nil, newError(ctx, "WithParametersLinearUnit", crs.String(), ErrNotACRS, "Expected a projected CRS")
+    }
+    name, factor, auth, code, release, e := alterUnit(ctx, "WithParametersLinearUnit", u, LinearUnit)
+    if e != nil {
+        return nil, e
+    }
+    defer release()
+    cconvert := C.int(0)
+    if convert {
+        cconvert = 1
+    }
+    return crs.alter(ctx, "WithParametersLinearUnit", func () *C.PJ {
+        return C.proj_crs_alter_parameters_linear_unit((*ctx).pj, (*crs).pj, name, factor, auth, code, cconvert)
+    })
+}
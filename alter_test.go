@@ -0,0 +1,152 @@
+package proj
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+// Tests :
+
+// TestWithLinearUnit checks EPSG:2154 in US survey feet.
+func TestWithLinearUnit ( t *testing.T ) {
+    crs, e := NewReferenceSystem(ctx, "EPSG:2154")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    ft, e := ctx.UnitOfMeasure("EPSG", "9003")
+    if e != nil {
+        t.Fatal(e)
+    }
+    c, e := crs.WithLinearUnit(ctx, ft)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyReferenceSystem()
+    if wkt := c.Wkt(ctx, WKTv2r2018, "MULTILINE=NO") ; !strings.Contains(wkt, `LENGTHUNIT["US survey foot"`) {
+        t.Errorf("Expected US survey foot in '%s'", wkt)
+    }
+    if wkt := crs.Wkt(ctx, WKTv2r2018, "MULTILINE=NO") ; strings.Contains(wkt, "US survey foot") {
+        t.Errorf("Unexpected US survey foot in '%s'", wkt)
+    }
+    if _, e = crs.WithLinearUnit(ctx, nil) ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected ErrInvalidParameter, but got %v", e)
+    }
+    grad, _ := GetAngularUnitByID("grad")
+    if _, e = crs.WithLinearUnit(ctx, grad) ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected ErrInvalidParameter, but got %v", e)
+    }
+}
+
+// TestWithParametersLinearUnit checks the parameters are converted.
+func TestWithParametersLinearUnit ( t *testing.T ) {
+    crs, e := NewReferenceSystem(ctx, "EPSG:2154")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    ft, _ := GetUnitByID("ft")
+    c, e := crs.WithParametersLinearUnit(ctx, ft, true)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyReferenceSystem()
+    // false easting of 700000 m
+    if wkt := c.Wkt(ctx, WKTv2r2018, "MULTILINE=NO") ; !strings.Contains(wkt, "2296587.") {
+        t.Errorf("Expected the false easting in feet in '%s'", wkt)
+    }
+    wgs84, _ := NewReferenceSystem(ctx, "EPSG:4326")
+    defer wgs84.DestroyReferenceSystem()
+    if _, e = wgs84.WithParametersLinearUnit(ctx, ft, true) ; !errors.Is(e, ErrNotACRS) {
+        t.Errorf("Expected ErrNotACRS, but got %v", e)
+    }
+}
+
+// TestWithAngularUnit checks a geographic CRS in grads.
+func TestWithAngularUnit ( t *testing.T ) {
+    crs, e := NewReferenceSystem(ctx, "EPSG:4326")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    grad, _ := GetAngularUnitByID("grad")
+    c, e := crs.WithAngularUnit(ctx, grad)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyReferenceSystem()
+    cs, e := c.CoordinateSystem(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer cs.DestroyCoordinateSystem()
+    axes, e := cs.Axes(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    for _, axis := range axes {
+        if d := axis.Unit.ToSI() - grad.ToSI() ; d > 1e-12 || d < -1e-12 {
+            t.Errorf("Expected grads, but got %s", axis.Unit.Name())
+        }
+    }
+}
+
+// TestWithNameAndIdentifier checks a renamed CRS with an internal code.
+func TestWithNameAndIdentifier ( t *testing.T ) {
+    crs, e := NewReferenceSystem(ctx, "EPSG:2154")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    named, e := crs.WithName(ctx, "Site grid")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer named.DestroyReferenceSystem()
+    if d := named.Info().Description() ; d != "Site grid" {
+        t.Errorf("Expected 'Site grid', but got '%s'", d)
+    }
+    c, e := named.WithIdentifier(ctx, "MYCOMPANY", "1001")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyReferenceSystem()
+    if wkt := c.Wkt(ctx, WKTv2r2018, "MULTILINE=NO") ; !strings.Contains(wkt, `ID["MYCOMPANY","1001"]`) || strings.Contains(wkt, `ID["EPSG",2154]`) {
+        t.Errorf("Expected MYCOMPANY:1001 in '%s'", wkt)
+    }
+    if _, e = crs.WithIdentifier(ctx, "", "1001") ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected ErrInvalidParameter, but got %v", e)
+    }
+}
+
+// TestWithGeodeticCRS checks a projection moved to another datum.
+func TestWithGeodeticCRS ( t *testing.T ) {
+    crs, e := NewReferenceSystem(ctx, "EPSG:32631")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    ed50, e := NewReferenceSystem(ctx, "EPSG:4230")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer ed50.DestroyReferenceSystem()
+    c, e := crs.WithGeodeticCRS(ctx, ed50)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyReferenceSystem()
+    if c.TypeOf() != ProjectedCRS {
+        t.Errorf("Expected a projected CRS, but got %v", c.TypeOf())
+    }
+    if wkt := c.Wkt(ctx, WKTv2r2018, "MULTILINE=NO") ; !strings.Contains(wkt, "European Datum 1950") || !strings.Contains(wkt, "UTM zone 31N") {
+        t.Errorf("Expected UTM 31N on ED50 in '%s'", wkt)
+    }
+    if _, e = crs.WithGeodeticCRS(ctx, crs) ; !errors.Is(e, ErrNotACRS) {
+        t.Errorf("Expected ErrNotACRS, but got %v", e)
+    }
+    if _, e = crs.WithGeodeticCRS(ctx, nil) ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected ErrInvalidParameter, but got %v", e)
+    }
+}
@@ -7,6 +7,10 @@ package proj
  */
 import "C"
 
+import (
+    "runtime"
+)
+
 // Area contains a opaque object describing an area in which a transformation is performed.
 //
 type Area struct {
@@ -29,6 +33,11 @@ var (
 //
 func NewArea ( lonmin float64, latmin float64, lonmax float64, latmax float64) (*Area) {
     a := &Area{pj:C.proj_area_create()}
+    allocated()
+    runtime.SetFinalizer(a, func ( a *Area ) {
+        leaked(a)
+        a.DestroyArea()
+    })
     C.proj_area_set_bbox(a.pj, C.double(lonmin), C.double(latmin), C.double(lonmax), C.double(latmax))
     return a
 }
@@ -36,12 +45,22 @@ func NewArea ( lonmin float64, latmin float64, lonmax float64, latmax float64) (
 // DestroyArea deallocates the internal PROJ area pointer
 //
 func (a *Area) DestroyArea () {
-    if a != nil {
+    if a != nil && (*a).pj != nil {
         C.proj_area_destroy((*a).pj)
         (*a).pj = nil
+        released()
+        runtime.SetFinalizer(a, nil)
     }
 }
 
+// Close deallocates the internal PROJ area pointer. It implements
+// io.Closer.
+//
+func (a *Area) Close () error {
+    a.DestroyArea()
+    return nil
+}
+
 // Handle returns the PROJ internal object to be passed to the PROJ library
 //
 func (a *Area) Handle () (interface{}) {
@@ -63,13 +82,3 @@ func (a *Area) SetBBox ( lonmin float64, latmin float64, lonmax float64, latmax
     C.proj_area_set_bbox(a.pj, C.double(lonmin), C.double(latmin), C.double(lonmax), C.double(latmax))
 }
  */
-
-/* TODO how to destroy opaque object in the end ?
-// init package initialisation
-//
-func init () {
-    runtime.SetFinalizer(World, func (a *Area) {
-        a.DestroyArea()
-    }
-}
- */
@@ -0,0 +1,278 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj -lsqlite3
+#include "wrapper.h"
+#include <sqlite3.h>
+
+// sqliteBindText binds a copy of the string, SQLITE_TRANSIENT being a macro
+// cgo cannot use.
+static int sqliteBindText ( sqlite3_stmt *stmt, int i, const char *v ) {
+    return sqlite3_bind_text(stmt, i, v, -1, SQLITE_TRANSIENT);
+}
+ */
+import "C"
+
+import (
+    "fmt"
+    "os"
+    "strings"
+    "unsafe"
+)
+
+// AuxiliaryCRS holds the definition of a CRS to be stored in an auxiliary
+// database.
+//
+type AuxiliaryCRS struct {
+    // Authority is the authority name, e.g. "MYCOMPANY"
+    Authority   string
+    // Code is the code of the CRS within the authority, e.g. "1001"
+    Code        string
+    // Name is the name of the CRS, the one of the definition when empty
+    Name        string
+    // Definition is the WKT, PROJJSON or proj-string definition of a
+    // geographic, geocentric or projected CRS
+    Definition  string
+    // AreaOfUse is the "authority:code" identifier of the area of use of
+    // the CRS in the database, empty for the world ("EPSG:1262")
+    AreaOfUse   string
+}
+
+// auxiliaryTables maps the types of CRS to the tables of the database and
+// the value of their type column, if any.
+//
+var auxiliaryTables = map[ISOType][2]string{
+    Geographic2DCRS : {"geodetic_crs", "geographic 2D"},
+    Geographic3DCRS : {"geodetic_crs", "geographic 3D"},
+    GeocentricCRS   : {"geodetic_crs", "geocentric"},
+    ProjectedCRS    : {"projected_crs", ""},
+}
+
+// CreateAuxiliaryDatabase creates at `p` an auxiliary database holding the
+// CRS definitions, to be added to a context with `AddAuxiliaryDatabase` or
+// `SetDatabasePath`. The structure of the database, triggers and indexes
+// included, is copied from the database of `ctx`. Definitions are checked
+// beforehand; the file must not exist.
+//
+//   e := CreateAuxiliaryDatabase(ctx, "/opt/mycompany/crs.db", []AuxiliaryCRS{
+//       {Authority:"MYCOMPANY", Code:"1001", Name:"Site grid", Definition:"+proj=tmerc +lon_0=2.5 +ellps=GRS80 +type=crs"},
+//   })
+//
+func CreateAuxiliaryDatabase ( ctx *Context, p string, crss []AuxiliaryCRS ) ( e error ) {
+    main := ctx.DatabasePath()
+    if main == "" {
+        return newError(ctx, "CreateAuxiliaryDatabase", p, nil, "No database")
+    }
+    if _, se := os.Stat(p) ; se == nil {
+        return newError(ctx, "CreateAuxiliaryDatabase", p, nil, "File already exists")
+    }
+    rows := make([]map[string]string, len(crss))
+    tables := make([]string, len(crss))
+    for i, def := range crss {
+        if rows[i], tables[i], e = auxiliaryRow(ctx, def) ; e != nil {
+            return
+        }
+    }
+    schema, e := databaseSchema(ctx, main)
+    if e != nil {
+        return
+    }
+    db, e := openDatabase(ctx, p, C.SQLITE_OPEN_READWRITE | C.SQLITE_OPEN_CREATE)
+    if e != nil {
+        return
+    }
+    defer func () {
+        C.sqlite3_close(db)
+        if e != nil {
+            os.Remove(p)
+        }
+    }()
+    if e = execDatabase(ctx, db, p, "BEGIN") ; e != nil {
+        return
+    }
+    for _, sql := range schema {
+        if e = execDatabase(ctx, db, p, sql) ; e != nil {
+            return
+        }
+    }
+    for i := range rows {
+        if e = insertDatabase(ctx, db, p, tables[i], rows[i]) ; e != nil {
+            return
+        }
+    }
+    return execDatabase(ctx, db, p, "COMMIT")
+}
+
+// auxiliaryRow checks the definition and returns the values of the columns
+// of its row, and its table.
+//
+func auxiliaryRow ( ctx *Context, def AuxiliaryCRS ) ( map[string]string, string, error ) {
+    id := def.Authority + ":" + def.Code
+    if def.Authority == "" || def.Code == "" {
+        return nil, "", newError(ctx, "CreateAuxiliaryDatabase", id, ErrInvalidDefinition, "Missing authority or code")
+    }
+    crs, e := NewReferenceSystem(ctx, def.Definition)
+    if e != nil {
+        return nil, "", e
+    }
+    defer crs.DestroyReferenceSystem()
+    table, ok := auxiliaryTables[crs.TypeOf()]
+    if !ok {
+        return nil, "", newError(ctx, "CreateAuxiliaryDatabase", id, ErrInvalidDefinition, "Only geographic, geocentric and projected CRS are supported")
+    }
+    area := []string{"EPSG", "1262"}
+    if def.AreaOfUse != "" {
+        if area = strings.SplitN(def.AreaOfUse, ":", 2) ; len(area) != 2 || area[0] == "" || area[1] == "" {
+            return nil, "", newError(ctx, "CreateAuxiliaryDatabase", id, ErrInvalidDefinition, fmt.Sprintf("Expected an 'authority:code' area of use, but got '%s'", def.AreaOfUse))
+        }
+    }
+    name := def.Name
+    if name == "" {
+        name = crs.Info().Description()
+    }
+    // the database only understands proj-strings and WKT
+    text := strings.TrimSpace(def.Definition)
+    if !strings.HasPrefix(text, "+") {
+        if text = crs.Wkt(ctx, WKTv2r2018, "MULTILINE=NO") ; text == "" {
+            return nil, "", contextError(ctx, "CreateAuxiliaryDatabase", id, ErrInvalidDefinition)
+        }
+    }
+    row := map[string]string{
+        "auth_name"             : def.Authority,
+        "code"                  : def.Code,
+        "name"                  : name,
+        "text_definition"       : text,
+        "deprecated"            : "0",
+        // PROJ 6 requires an area of use
+        "area_of_use_auth_name" : area[0],
+        "area_of_use_code"      : area[1],
+    }
+    if table[1] != "" {
+        row["type"] = table[1]
+    }
+    return row, table[0], nil
+}
+
+// databaseSchema returns the statements creating the tables, views, indexes
+// and triggers of a database, each kind in its creation order.
+//
+func databaseSchema ( ctx *Context, p string ) ( []string, error ) {
+    db, e := openDatabase(ctx, p, C.SQLITE_OPEN_READONLY)
+    if e != nil {
+        return nil, e
+    }
+    defer C.sqlite3_close(db)
+    var schema []string
+    e = queryDatabase(ctx, db, p, "SELECT sql FROM sqlite_master WHERE type IN ('table', 'view', 'index', 'trigger') AND name NOT LIKE 'sqlite_%' AND sql IS NOT NULL ORDER BY CASE type WHEN 'table' THEN 0 WHEN 'view' THEN 1 WHEN 'index' THEN 2 ELSE 3 END, rowid", func ( stmt *C.sqlite3_stmt ) {
+        schema = append(schema, C.GoString((*C.char)(unsafe.Pointer(C.sqlite3_column_text(stmt, 0)))))
+    })
+    return schema, e
+}
+
+// databaseMetadata returns every key of the metadata table of a database
+// with its value.
+//
+func databaseMetadata ( ctx *Context, p string ) ( map[string]string, error ) {
+    db, e := openDatabase(ctx, p, C.SQLITE_OPEN_READONLY)
+    if e != nil {
+        return nil, e
+    }
+    defer C.sqlite3_close(db)
+    md := make(map[string]string)
+    e = queryDatabase(ctx, db, p, "SELECT key, value FROM metadata", func ( stmt *C.sqlite3_stmt ) {
+        k := C.GoString((*C.char)(unsafe.Pointer(C.sqlite3_column_text(stmt, 0))))
+        md[k] = C.GoString((*C.char)(unsafe.Pointer(C.sqlite3_column_text(stmt, 1))))
+    })
+    return md, e
+}
+
+// insertDatabase inserts a row into the table, ignoring the values of the
+// columns the table does not have.
+//
+func insertDatabase ( ctx *Context, db *C.sqlite3, p string, table string, row map[string]string ) error {
+    var cols []string
+    e := queryDatabase(ctx, db, p, fmt.Sprintf("PRAGMA table_info(%s)", table), func ( stmt *C.sqlite3_stmt ) {
+        col := C.GoString((*C.char)(unsafe.Pointer(C.sqlite3_column_text(stmt, 1))))
+        if _, ok := row[col] ; ok {
+            cols = append(cols, col)
+        }
+    })
+    if e != nil {
+        return e
+    }
+    sql := fmt.Sprintf("INSERT INTO %s(%s) VALUES (%s)", table, strings.Join(cols, ","), strings.TrimSuffix(strings.Repeat("?,", len(cols)), ","))
+    csql := C.CString(sql)
+    defer C.free(unsafe.Pointer(csql))
+    var stmt *C.sqlite3_stmt
+    if C.sqlite3_prepare_v2(db, csql, -1, &stmt, nil) != C.SQLITE_OK {
+        return databaseError(ctx, db, p)
+    }
+    defer C.sqlite3_finalize(stmt)
+    for i, col := range cols {
+        v := C.CString(row[col])
+        C.sqliteBindText(stmt, C.int(i+1), v)
+        C.free(unsafe.Pointer(v))
+    }
+    if C.sqlite3_step(stmt) != C.SQLITE_DONE {
+        return databaseError(ctx, db, p)
+    }
+    return nil
+}
+
+// openDatabase opens a SQLite database.
+//
+func openDatabase ( ctx *Context, p string, flags C.int ) ( *C.sqlite3, error ) {
+    cp := C.CString(p)
+    defer C.free(unsafe.Pointer(cp))
+    var db *C.sqlite3
+    if C.sqlite3_open_v2(cp, &db, flags, nil) != C.SQLITE_OK {
+        e := databaseError(ctx, db, p)
+        C.sqlite3_close(db)
+        return nil, e
+    }
+    return db, nil
+}
+
+// execDatabase runs a statement returning no row.
+//
+func execDatabase ( ctx *Context, db *C.sqlite3, p string, sql string ) error {
+    csql := C.CString(sql)
+    defer C.free(unsafe.Pointer(csql))
+    if C.sqlite3_exec(db, csql, nil, nil, nil) != C.SQLITE_OK {
+        return databaseError(ctx, db, p)
+    }
+    return nil
+}
+
+// queryDatabase runs a query, calling `f` for each row.
+//
+func queryDatabase ( ctx *Context, db *C.sqlite3, p string, sql string, f func ( stmt *C.sqlite3_stmt ) ) error {
+    csql := C.CString(sql)
+    defer C.free(unsafe.Pointer(csql))
+    var stmt *C.sqlite3_stmt
+    if C.sqlite3_prepare_v2(db, csql, -1, &stmt, nil) != C.SQLITE_OK {
+        return databaseError(ctx, db, p)
+    }
+    defer C.sqlite3_finalize(stmt)
+    for {
+        switch C.sqlite3_step(stmt) {
+        case C.SQLITE_ROW :
+            f(stmt)
+        case C.SQLITE_DONE :
+            return nil
+        default :
+            return databaseError(ctx, db, p)
+        }
+    }
+}
+
+// databaseError returns the last SQLite error of the database.
+//
+func databaseError ( ctx *Context, db *C.sqlite3, p string ) error {
+    msg := "Out of memory"
+    if db != nil {
+        msg = C.GoString(C.sqlite3_errmsg(db))
+    }
+    return newError(ctx, "CreateAuxiliaryDatabase", p, nil, msg)
+}
@@ -0,0 +1,68 @@
+package proj
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+// Tests :
+
+// TestAuxiliaryDatabase checks company-specific CRS are resolved once their
+// database is added.
+func TestAuxiliaryDatabase ( t *testing.T ) {
+    c := NewContext()
+    defer c.DestroyContext()
+    p := filepath.Join(t.TempDir(), "mycompany.db")
+    e := CreateAuxiliaryDatabase(c, p, []AuxiliaryCRS{
+        {Authority:"MYCOMPANY", Code:"1001", Name:"Site grid", Definition:"+proj=tmerc +lon_0=2.5 +ellps=GRS80 +type=crs"},
+        {Authority:"MYCOMPANY", Code:"1002", Definition:"EPSG:4326", AreaOfUse:"EPSG:1096"},
+    })
+    if e != nil {
+        t.Fatal(e)
+    }
+    if e = CreateAuxiliaryDatabase(c, p, nil) ; e == nil {
+        t.Errorf("Expected an error when the file already exists")
+    }
+    if e = c.AddAuxiliaryDatabase(p) ; e != nil {
+        t.Fatal(e)
+    }
+    if paths := c.AuxiliaryDatabasePaths() ; len(paths) != 1 || paths[0] != p {
+        t.Errorf("Expected auxiliary databases [%s], but got %v", p, paths)
+    }
+    if e = c.SetDatabasePath(c.DatabasePath(), filepath.Join(t.TempDir(), "missing.db")) ; e == nil {
+        t.Errorf("Expected an error for a missing auxiliary database")
+    }
+    if paths := c.AuxiliaryDatabasePaths() ; len(paths) != 1 || paths[0] != p {
+        t.Errorf("Expected auxiliary databases [%s] to be kept, but got %v", p, paths)
+    }
+    if !c.IsAnAuthority("MYCOMPANY") {
+        t.Errorf("Expected MYCOMPANY to be an authority")
+    }
+    for _, id := range []string{"MYCOMPANY:1001", "MYCOMPANY:1002"} {
+        crs, e := NewReferenceSystem(c, id)
+        if e != nil {
+            t.Errorf("Expected %s to be resolved, but got %v", id, e)
+            continue
+        }
+        crs.DestroyReferenceSystem()
+    }
+    if e = CreateAuxiliaryDatabase(c, filepath.Join(t.TempDir(), "bad.db"), []AuxiliaryCRS{{Authority:"MYCOMPANY", Code:"1003", Definition:"+proj=utm +zone=31 +ellps=WGS84"}}) ; e == nil {
+        t.Errorf("Expected an error for an operation")
+    }
+    if e = CreateAuxiliaryDatabase(c, filepath.Join(t.TempDir(), "area.db"), []AuxiliaryCRS{{Authority:"MYCOMPANY", Code:"1004", Definition:"EPSG:4326", AreaOfUse:"1096"}}) ; !errors.Is(e, ErrInvalidDefinition) {
+        t.Errorf("Expected ErrInvalidDefinition for an area of use without authority, but got %v", e)
+    }
+    // the triggers of the database reject duplicate codes
+    dup := filepath.Join(t.TempDir(), "dup.db")
+    if e = CreateAuxiliaryDatabase(c, dup, []AuxiliaryCRS{
+        {Authority:"MYCOMPANY", Code:"1005", Definition:"EPSG:4326"},
+        {Authority:"MYCOMPANY", Code:"1005", Definition:"EPSG:4258"},
+    }) ; e == nil {
+        t.Errorf("Expected an error for a duplicate code")
+    }
+    if _, se := os.Stat(dup) ; se == nil {
+        t.Errorf("Expected %s to be removed", dup)
+    }
+}
@@ -0,0 +1,219 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "fmt"
+    "math"
+    "runtime"
+    "unsafe"
+)
+
+// towgs84Param describes a parameter of the Bursa-Wolf transformations.
+//
+type towgs84Param struct {
+    name    string
+    code    string
+    unit    string
+    factor  float64
+    typ     C.PJ_UNIT_TYPE
+}
+
+// towgs84Params are the parameters of the Bursa-Wolf transformations, in the
+// order of the TOWGS84 values.
+//
+var towgs84Params = []towgs84Param{
+    {"X-axis translation", "8605", "metre", 1.0, C.PJ_UT_LINEAR},
+    {"Y-axis translation", "8606", "metre", 1.0, C.PJ_UT_LINEAR},
+    {"Z-axis translation", "8607", "metre", 1.0, C.PJ_UT_LINEAR},
+    {"X-axis rotation", "8608", "arc-second", math.Pi / 648000.0, C.PJ_UT_ANGULAR},
+    {"Y-axis rotation", "8609", "arc-second", math.Pi / 648000.0, C.PJ_UT_ANGULAR},
+    {"Z-axis rotation", "8610", "arc-second", math.Pi / 648000.0, C.PJ_UT_ANGULAR},
+    {"Scale difference", "8611", "parts per million", 1e-6, C.PJ_UT_SCALE},
+}
+
+// NewBoundCRS creates a bound reference system, that is a reference system
+// bound to a hub reference system, often WGS 84, by a transformation.
+//
+func NewBoundCRS ( ctx *Context, base *ReferenceSystem, hub *ReferenceSystem, transformation *Operation ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    if base.TypeOf() == BoundCRS {
+        e = newError(ctx, "NewBoundCRS", base.String(), ErrInvalidParameter, "Already a bound CRS")
+        return
+    }
+    if transformation.TypeOf() != Transformation {
+        e = newError(ctx, "NewBoundCRS", base.String(), ErrNotAnOperation, "Expected a transformation")
+        return
+    }
+    defer base.use.enter(base, (*base).ctx, ctx)()
+    defer hub.use.enter(hub, (*hub).ctx, ctx)()
+    defer transformation.use.enter(transformation, (*transformation).ctx, ctx)()
+    defer runtime.KeepAlive(base)
+    defer runtime.KeepAlive(hub)
+    defer runtime.KeepAlive(transformation)
+    pj := C.proj_crs_create_bound_crs((*ctx).pj, (*base).pj, (*hub).pj, (*transformation).pj)
+    if pj == nil {
+        e = contextError(ctx, "NewBoundCRS", base.String(), ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
+
+// NewBoundCRSToWGS84 creates a reference system bound to WGS 84 by the
+// Bursa-Wolf parameters `towgs84` : 3 translations in meters, or 3
+// translations, 3 rotations in arc-seconds (position vector convention) and
+// a scale difference in parts per million. Without parameters, the
+// transformation is looked up in the database.
+//
+//   ed50, _ := NewReferenceSystem(ctx, "EPSG:4230")
+//   crs, e := NewBoundCRSToWGS84(ctx, ed50, -87, -98, -121)
+//
+func NewBoundCRSToWGS84 ( ctx *Context, base *ReferenceSystem, towgs84 ...float64 ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    if base.TypeOf() == BoundCRS {
+        e = newError(ctx, "NewBoundCRSToWGS84", base.String(), ErrInvalidParameter, "Already a bound CRS")
+        return
+    }
+    if len(towgs84) == 0 {
+        defer base.use.enter(base, (*base).ctx, ctx)()
+        defer runtime.KeepAlive(base)
+        pj := C.proj_crs_create_bound_crs_to_WGS84((*ctx).pj, (*base).pj, nil)
+        if pj == nil {
+            e = contextError(ctx, "NewBoundCRSToWGS84", base.String(), ErrNoOperation)
+            return
+        }
+        crs = newReferenceSystem(ctx, pj)
+        return
+    }
+    if len(towgs84) != 3 && len(towgs84) != 7 {
+        e = newError(ctx, "NewBoundCRSToWGS84", base.String(), ErrInvalidParameter, fmt.Sprintf("Expected 3 or 7 TOWGS84 values, but got %d", len(towgs84)))
+        return
+    }
+    for i, v := range towgs84 {
+        if math.IsNaN(v) || math.IsInf(v, 0) {
+            e = newError(ctx, "NewBoundCRSToWGS84", base.String(), ErrInvalidParameter, fmt.Sprintf("Expected a finite %s, but got %v", towgs84Params[i].name, v))
+            return
+        }
+    }
+    wgs84, e := NewReferenceSystem(ctx, "EPSG:4326")
+    if e != nil {
+        return
+    }
+    defer wgs84.DestroyReferenceSystem()
+    tr, e := newTOWGS84Transformation(ctx, base, wgs84, towgs84)
+    if e != nil {
+        return
+    }
+    defer tr.DestroyOperation()
+    return NewBoundCRS(ctx, base, wgs84, tr)
+}
+
+// newTOWGS84Transformation creates the Bursa-Wolf transformation from the
+// geodetic reference system of `base` to WGS 84.
+//
+func newTOWGS84Transformation ( ctx *Context, base *ReferenceSystem, wgs84 *ReferenceSystem, towgs84 []float64 ) ( *Operation, error ) {
+    defer base.use.enter(base, (*base).ctx, ctx)()
+    defer runtime.KeepAlive(base)
+    defer runtime.KeepAlive(wgs84)
+    src := C.proj_crs_get_geodetic_crs((*ctx).pj, (*base).pj)
+    if src == nil {
+        return nil, contextError(ctx, "NewBoundCRSToWGS84", base.String(), ErrNotACRS)
+    }
+    defer C.proj_destroy(src)
+    method, code := "Geocentric translations (geog2D domain)", "9603"
+    if len(towgs84) == 7 {
+        method, code = "Position Vector transformation (geog2D domain)", "9606"
+    }
+    cparams := (*C.PJ_PARAM_DESCRIPTION)(C.calloc(C.size_t(len(towgs84)), C.size_t(unsafe.Sizeof(C.PJ_PARAM_DESCRIPTION{}))))
+    defer C.free(unsafe.Pointer(cparams))
+    cps := unsafe.Slice(cparams, len(towgs84))
+    epsg := C.CString("EPSG")
+    defer C.free(unsafe.Pointer(epsg))
+    for i, v := range towgs84 {
+        p := towgs84Params[i]
+        cps[i].name = C.CString(p.name)
+        defer C.free(unsafe.Pointer(cps[i].name))
+        cps[i].auth_name = epsg
+        cps[i].code = C.CString(p.code)
+        defer C.free(unsafe.Pointer(cps[i].code))
+        cps[i].value = C.double(v)
+        cps[i].unit_name = C.CString(p.unit)
+        defer C.free(unsafe.Pointer(cps[i].unit_name))
+        cps[i].unit_conv_factor = C.double(p.factor)
+        cps[i].unit_type = p.typ
+    }
+    cname := C.CString(fmt.Sprintf("Transformation from %s to WGS84", C.GoString(C.proj_get_name(src))))
+    defer C.free(unsafe.Pointer(cname))
+    cmethod := C.CString(method)
+    defer C.free(unsafe.Pointer(cmethod))
+    ccode := C.CString(code)
+    defer C.free(unsafe.Pointer(ccode))
+    pj := C.proj_create_transformation((*ctx).pj, cname, nil, nil, src, (*wgs84).pj, nil, cmethod, epsg, ccode, C.int(len(towgs84)), cparams, -1.0)
+    if pj == nil {
+        return nil, contextError(ctx, "NewBoundCRSToWGS84", base.String(), ErrInvalidParameter)
+    }
+    return newOperation(ctx, pj), nil
+}
+
+// TOWGS84 returns the 7 Bursa-Wolf parameters binding the reference system
+// to WGS 84 (see `NewBoundCRSToWGS84`), nil when the reference system is not
+// bound to WGS 84.
+//
+func (crs *ReferenceSystem) TOWGS84 ( ctx *Context ) ( []float64, error ) {
+    if crs.TypeOf() != BoundCRS {
+        return nil, nil
+    }
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    op := C.proj_crs_get_coordoperation((*ctx).pj, (*crs).pj)
+    if op == nil {
+        return nil, contextError(ctx, "TOWGS84", crs.String(), ErrNotAnOperation)
+    }
+    defer C.proj_destroy(op)
+    var values [7]C.double
+    if C.proj_coordoperation_get_towgs84_values((*ctx).pj, op, &values[0], 7, 0) == 0 {
+        return nil, nil
+    }
+    towgs84 := make([]float64, len(values))
+    for i, v := range values {
+        towgs84[i] = float64(v)
+    }
+    return towgs84, nil
+}
+
+// WithTOWGS84 returns a copy of the reference system bound to WGS 84 by the
+// Bursa-Wolf parameters `towgs84` (see `NewBoundCRSToWGS84`), replacing its
+// former binding if any.
+//
+func (crs *ReferenceSystem) WithTOWGS84 ( ctx *Context, towgs84 ...float64 ) ( *ReferenceSystem, error ) {
+    base, e := crs.WithoutTOWGS84(ctx)
+    if e != nil {
+        return nil, e
+    }
+    defer base.DestroyReferenceSystem()
+    return NewBoundCRSToWGS84(ctx, base, towgs84...)
+}
+
+// WithoutTOWGS84 returns the base reference system of a bound reference
+// system, a copy of the reference system otherwise.
+//
+func (crs *ReferenceSystem) WithoutTOWGS84 ( ctx *Context ) ( *ReferenceSystem, error ) {
+    if crs.TypeOf() != BoundCRS {
+        return crs.Clone(ctx)
+    }
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    pj := C.proj_get_source_crs((*ctx).pj, (*crs).pj)
+    if pj == nil {
+        return nil, contextError(ctx, "WithoutTOWGS84", crs.String(), ErrNotACRS)
+    }
+    return newReferenceSystem(ctx, pj), nil
+}
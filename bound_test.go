@@ -0,0 +1,83 @@
+package proj
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+// Tests :
+
+// TestBoundCRSToWGS84 checks TOWGS84 values are set, read, replaced and
+// stripped.
+func TestBoundCRSToWGS84 ( t *testing.T ) {
+    ed50, e := NewReferenceSystem(ctx, "EPSG:4230")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer ed50.DestroyReferenceSystem()
+    if towgs84, _ := ed50.TOWGS84(ctx) ; towgs84 != nil {
+        t.Errorf("Unexpected TOWGS84 %v", towgs84)
+    }
+    crs, e := NewBoundCRSToWGS84(ctx, ed50, -87, -98, -121)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    if crs.TypeOf() != BoundCRS {
+        t.Errorf("Expected a bound CRS, but got %v", crs.TypeOf())
+    }
+    checkTOWGS84(t, crs, []float64{-87, -98, -121, 0, 0, 0, 0})
+    if wkt := crs.Wkt(ctx, WKTv1GDAL) ; !strings.Contains(wkt, "TOWGS84[-87,-98,-121,0,0,0,0]") {
+        t.Errorf("Expected TOWGS84 in '%s'", wkt)
+    }
+    crs7, e := crs.WithTOWGS84(ctx, -89.5, -93.8, -123.1, 0, 0, -0.156, 1.2)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs7.DestroyReferenceSystem()
+    checkTOWGS84(t, crs7, []float64{-89.5, -93.8, -123.1, 0, 0, -0.156, 1.2})
+    base, e := crs7.WithoutTOWGS84(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer base.DestroyReferenceSystem()
+    if base.TypeOf() != Geographic2DCRS {
+        t.Errorf("Expected a geographic CRS, but got %v", base.TypeOf())
+    }
+    if _, e = NewBoundCRSToWGS84(ctx, ed50, 1, 2) ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected ErrInvalidParameter, but got %v", e)
+    }
+}
+
+// TestTOWGS84Wkt checks TOWGS84 values of WKT1 definitions survive.
+func TestTOWGS84Wkt ( t *testing.T ) {
+    wkt := `GEOGCS["ED50",DATUM["European_Datum_1950",SPHEROID["International 1924",6378388,297],TOWGS84[-87,-98,-121,0,0,0,0]],PRIMEM["Greenwich",0],UNIT["degree",0.0174532925199433]]`
+    crs, e := NewReferenceSystem(ctx, wkt)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    checkTOWGS84(t, crs, []float64{-87, -98, -121, 0, 0, 0, 0})
+    if out := crs.Wkt(ctx, WKTv1GDAL, "MULTILINE=NO") ; !strings.Contains(out, "TOWGS84[-87,-98,-121,0,0,0,0]") {
+        t.Errorf("Expected TOWGS84 in '%s'", out)
+    }
+}
+
+// checkTOWGS84 compares the TOWGS84 values of the reference system.
+func checkTOWGS84 ( t *testing.T, crs *ReferenceSystem, expected []float64 ) {
+    t.Helper()
+    towgs84, e := crs.TOWGS84(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(towgs84) != len(expected) {
+        t.Fatalf("Expected TOWGS84 %v, but got %v", expected, towgs84)
+    }
+    for i := range expected {
+        if d := towgs84[i] - expected[i] ; d > 1e-9 || d < -1e-9 {
+            t.Errorf("Expected TOWGS84 %v, but got %v", expected, towgs84)
+            return
+        }
+    }
+}
@@ -0,0 +1,41 @@
+package proj
+
+import (
+    "sync"
+    "sync/atomic"
+    "weak"
+)
+
+var (
+    // last identifier given to a context
+    lastContextID uint64
+    // contexts reachable from the C callbacks, keyed by their identifier
+    callbackContexts sync.Map
+)
+
+// register gives the context an identifier to be passed as user data to the
+// PROJ callbacks. `callbackContexts` holds a weak pointer so that the context can
+// still be released by the garbage collector, hence Go 1.24 at least.
+//
+func register ( ctx *Context ) {
+    (*ctx).id = uintptr(atomic.AddUint64(&lastContextID, 1))
+    callbackContexts.Store((*ctx).id, weak.Make(ctx))
+}
+
+// unregister removes the context from `callbackContexts`.
+//
+func unregister ( ctx *Context ) {
+    if (*ctx).id != 0 {
+        callbackContexts.Delete((*ctx).id)
+        (*ctx).id = 0
+    }
+}
+
+// lookup returns the context registered under the identifier, nil if none.
+//
+func lookup ( id uintptr ) *Context {
+    if p, ok := callbackContexts.Load(id) ; ok {
+        return p.(weak.Pointer[Context]).Value()
+    }
+    return nil
+}
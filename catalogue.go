@@ -0,0 +1,248 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "fmt"
+    "math"
+    "runtime"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// EllipsoidEntry describes an ellipsoid built in PROJ, that is a legal
+// value of the +ellps= parameter.
+//
+type EllipsoidEntry struct {
+    // ID is the keyword name, e.g. "GRS80"
+    ID                  string
+    // SemiMajor is the semi-major axis in meters, e.g. 6378137
+    SemiMajor           float64
+    // SemiMinor is the semi-minor axis in meters, e.g. 6356752.314140347
+    SemiMinor           float64
+    // InverseFlattening is the inverse flattening, e.g. 298.257222101, 0
+    // for a sphere
+    InverseFlattening   float64
+    // Name is the name of the ellipsoid, e.g. "GRS 1980(IUGG, 1980)"
+    Name                string
+}
+
+// parse sets the axes and the inverse flattening from the parameters of the
+// PROJ table, e.g. "a=6378137.0" and "rf=298.257222101" or "b=6356752.31414".
+//
+func (ee *EllipsoidEntry) parse ( major string, ell string ) error {
+    value := func ( param string, names ...string ) ( string, float64, error ) {
+        k, v, _ := strings.Cut(param, "=")
+        for _, n := range names {
+            if k == n {
+                f, e := strconv.ParseFloat(v, 64)
+                return k, f, e
+            }
+        }
+        return k, 0.0, fmt.Errorf("Unexpected parameter '%s' for ellipsoid '%s'", param, (*ee).ID)
+    }
+    _, a, e := value(major, "a")
+    if e != nil {
+        return e
+    }
+    k, v, e := value(ell, "rf", "b", "es", "e")
+    if e != nil {
+        return e
+    }
+    (*ee).SemiMajor = a
+    switch k {
+    case "rf" :
+        (*ee).InverseFlattening = v
+        (*ee).SemiMinor = a
+        if v != 0.0 {
+            (*ee).SemiMinor = a * (1.0 - 1.0/v)
+        }
+        return nil
+    case "b" :
+        (*ee).SemiMinor = v
+    case "es" :
+        (*ee).SemiMinor = a * math.Sqrt(1.0 - v)
+    case "e" :
+        (*ee).SemiMinor = a * math.Sqrt(1.0 - v*v)
+    }
+    if (*ee).SemiMinor != a {
+        (*ee).InverseFlattening = a / (a - (*ee).SemiMinor)
+    }
+    return nil
+}
+
+// PrimeMeridianEntry describes a prime meridian built in PROJ, that is a
+// legal value of the +pm= parameter.
+//
+type PrimeMeridianEntry struct {
+    // ID is the keyword name, e.g. "paris"
+    ID          string
+    // Definition is the offset from Greenwich in DMS format, e.g. "2d20'14.025"E"
+    Definition  string
+}
+
+// OperationEntry describes an operation method built in PROJ, that is a
+// legal value of the +proj= parameter.
+//
+type OperationEntry struct {
+    // ID is the keyword name, e.g. "lcc"
+    ID          string
+    // Description is the text describing the method and its parameters,
+    // e.g. "Lambert Conformal Conic\n\tConic, Sph&Ell\n\tlat_1= and lat_2= or lat_0, k_0="
+    Description string
+}
+
+var (
+    ellipsoidEntries        map[string]*EllipsoidEntry
+    primeMeridianEntries    map[string]*PrimeMeridianEntry
+    operationEntries        map[string]*OperationEntry
+)
+
+// GetEllipsoidEntryByID returns a built-in ellipsoid from its identifier.
+//
+func GetEllipsoidEntryByID ( id string ) ( ee *EllipsoidEntry, e error ) {
+    if ee = ellipsoidEntries[id] ; ee == nil {
+        e = newError(nil, "GetEllipsoidEntryByID", id, ErrInvalidParameter, "No ellipsoid with that identifier")
+    }
+    return
+}
+
+// EllipsoidEntries returns the built-in ellipsoids sorted by identifier.
+//
+func EllipsoidEntries () []*EllipsoidEntry {
+    ees := make([]*EllipsoidEntry, 0, len(ellipsoidEntries))
+    for _, ee := range ellipsoidEntries {
+        ees = append(ees, ee)
+    }
+    sort.Slice(ees, func ( i, j int ) bool { return ees[i].ID < ees[j].ID })
+    return ees
+}
+
+// Ellipsoid creates the ellipsoid object matching the entry.
+//
+func (ee *EllipsoidEntry) Ellipsoid ( ctx *Context ) ( *Ellipsoid, error ) {
+    crs, e := NewReferenceSystem(ctx, fmt.Sprintf("+proj=longlat +ellps=%s +type=crs", (*ee).ID))
+    if e != nil {
+        return nil, e
+    }
+    defer crs.DestroyReferenceSystem()
+    pj := C.proj_get_ellipsoid((*ctx).pj, (*crs).pj)
+    runtime.KeepAlive(ctx)
+    if pj == nil {
+        return nil, contextError(ctx, "Ellipsoid", (*ee).ID, ErrNotAnEllipsoid)
+    }
+    return newEllipsoid(ctx, pj), nil
+}
+
+// GetPrimeMeridianEntryByID returns a built-in prime meridian from its
+// identifier.
+//
+func GetPrimeMeridianEntryByID ( id string ) ( pme *PrimeMeridianEntry, e error ) {
+    if pme = primeMeridianEntries[id] ; pme == nil {
+        e = newError(nil, "GetPrimeMeridianEntryByID", id, ErrInvalidParameter, "No prime meridian with that identifier")
+    }
+    return
+}
+
+// PrimeMeridianEntries returns the built-in prime meridians sorted by
+// identifier.
+//
+func PrimeMeridianEntries () []*PrimeMeridianEntry {
+    pmes := make([]*PrimeMeridianEntry, 0, len(primeMeridianEntries))
+    for _, pme := range primeMeridianEntries {
+        pmes = append(pmes, pme)
+    }
+    sort.Slice(pmes, func ( i, j int ) bool { return pmes[i].ID < pmes[j].ID })
+    return pmes
+}
+
+// PrimeMeridian creates the prime meridian object matching the entry.
+//
+func (pme *PrimeMeridianEntry) PrimeMeridian ( ctx *Context ) ( *PrimeMeridian, error ) {
+    crs, e := NewReferenceSystem(ctx, fmt.Sprintf("+proj=longlat +ellps=WGS84 +pm=%s +type=crs", (*pme).ID))
+    if e != nil {
+        return nil, e
+    }
+    defer crs.DestroyReferenceSystem()
+    pj := C.proj_get_prime_meridian((*ctx).pj, (*crs).pj)
+    runtime.KeepAlive(ctx)
+    if pj == nil {
+        return nil, contextError(ctx, "PrimeMeridian", (*pme).ID, ErrNotAPrimeMeridian)
+    }
+    return newPrimeMeridian(ctx, pj), nil
+}
+
+// GetOperationEntryByID returns a built-in operation method from its
+// identifier.
+//
+func GetOperationEntryByID ( id string ) ( oe *OperationEntry, e error ) {
+    if oe = operationEntries[id] ; oe == nil {
+        e = newError(nil, "GetOperationEntryByID", id, ErrInvalidParameter, "No operation with that identifier")
+    }
+    return
+}
+
+// OperationEntries returns the built-in operation methods sorted by
+// identifier.
+//
+func OperationEntries () []*OperationEntry {
+    oes := make([]*OperationEntry, 0, len(operationEntries))
+    for _, oe := range operationEntries {
+        oes = append(oes, oe)
+    }
+    sort.Slice(oes, func ( i, j int ) bool { return oes[i].ID < oes[j].ID })
+    return oes
+}
+
+// Title returns the first line of the description, e.g. "Lambert Conformal
+// Conic".
+//
+func (oe *OperationEntry) Title () string {
+    t, _, _ := strings.Cut((*oe).Description, "\n")
+    return t
+}
+
+// init package initialisation
+//
+func init () {
+    n := int(C.nbEllipsoidsFromPROJ())
+    ellipsoidEntries = make(map[string]*EllipsoidEntry, n)
+    for i := 0 ; i < n ; i++ {
+        ce := C.getEllipsoidFromPROJ(C.int(i))
+        ee := &EllipsoidEntry{
+            ID:C.GoString((*ce).id),
+            Name:C.GoString((*ce).name),
+        }
+        if e := ee.parse(C.GoString((*ce).major), C.GoString((*ce).ell)) ; e != nil {
+            LogOnError(e)
+            continue
+        }
+        ellipsoidEntries[ee.ID] = ee
+    }
+    n = int(C.nbPrimeMeridiansFromPROJ())
+    primeMeridianEntries = make(map[string]*PrimeMeridianEntry, n)
+    for i := 0 ; i < n ; i++ {
+        cpm := C.getPrimeMeridianFromPROJ(C.int(i))
+        pme := &PrimeMeridianEntry{
+            ID:C.GoString((*cpm).id),
+            Definition:C.GoString((*cpm).defn),
+        }
+        primeMeridianEntries[pme.ID] = pme
+    }
+    n = int(C.nbOperationsFromPROJ())
+    operationEntries = make(map[string]*OperationEntry, n)
+    for i := 0 ; i < n ; i++ {
+        cop := C.getOperationFromPROJ(C.int(i))
+        oe := &OperationEntry{
+            ID:C.GoString((*cop).id),
+            Description:C.GoString(C.getOperationDescriptionFromPROJ(cop)),
+        }
+        operationEntries[oe.ID] = oe
+    }
+}
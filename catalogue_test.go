@@ -0,0 +1,88 @@
+package proj
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+// Tests :
+
+// TestEllipsoidEntries checks the built-in ellipsoids.
+func TestEllipsoidEntries ( t *testing.T ) {
+    ees := EllipsoidEntries()
+    if len(ees) == 0 {
+        t.Fatalf("Expected built-in ellipsoids")
+    }
+    for i := 1 ; i < len(ees) ; i++ {
+        if ees[i-1].ID >= ees[i].ID {
+            t.Errorf("Expected ellipsoids sorted by identifier, got '%s' before '%s'", ees[i-1].ID, ees[i].ID)
+        }
+    }
+    grs80, e := GetEllipsoidEntryByID("GRS80")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if grs80.SemiMajor != 6378137.0 || grs80.InverseFlattening != 298.257222101 || math.Abs(grs80.SemiMinor - 6356752.314140347) > 1e-6 {
+        t.Errorf("Unexpected GRS80 parameters %v %v %v", grs80.SemiMajor, grs80.SemiMinor, grs80.InverseFlattening)
+    }
+    // defined by its semi-minor axis
+    airy, e := GetEllipsoidEntryByID("airy")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if airy.SemiMajor != 6377563.396 || airy.SemiMinor != 6356256.910 || math.Abs(airy.InverseFlattening - 299.3249646) > 1e-6 {
+        t.Errorf("Unexpected Airy parameters %v %v %v", airy.SemiMajor, airy.SemiMinor, airy.InverseFlattening)
+    }
+    ell, e := grs80.Ellipsoid(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer ell.DestroyEllipsoid()
+    rf, e := ell.InverseFlattening(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if math.Abs(rf - 298.257222101) > 1e-9 {
+        t.Errorf("Expected 298.257222101, but got %f", rf)
+    }
+    if _, e = GetEllipsoidEntryByID("UnKnownEllipsoid") ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Unexpected 'UnKnownEllipsoid' ellipsoid")
+    }
+}
+
+// TestPrimeMeridianEntries checks the built-in prime meridians.
+func TestPrimeMeridianEntries ( t *testing.T ) {
+    if len(PrimeMeridianEntries()) == 0 {
+        t.Fatalf("Expected built-in prime meridians")
+    }
+    paris, e := GetPrimeMeridianEntryByID("paris")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if paris.Definition == "" {
+        t.Errorf("Expected a definition for the Paris meridian")
+    }
+    pm, e := paris.PrimeMeridian(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    pm.DestroyPrimeMeridian()
+}
+
+// TestOperationEntries checks the built-in operation methods.
+func TestOperationEntries ( t *testing.T ) {
+    if len(OperationEntries()) == 0 {
+        t.Fatalf("Expected built-in operation methods")
+    }
+    lcc, e := GetOperationEntryByID("lcc")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if lcc.Title() != "Lambert Conformal Conic" {
+        t.Errorf("Expected 'Lambert Conformal Conic', but got '%s'", lcc.Title())
+    }
+    if _, e = GetOperationEntryByID("UnKnownOperation") ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Unexpected 'UnKnownOperation' operation")
+    }
+}
@@ -0,0 +1,139 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "runtime"
+    "unsafe"
+)
+
+// NewVerticalCRS creates a vertical reference system from the name of its
+// datum. `linear` is the unit of the height, nil for meter. `geoidGrid`,
+// when not empty, is the grid of the geoid model relating the heights to
+// ellipsoidal heights, e.g. "egm96_15.gtx" :
+//
+//   crs, e := NewVerticalCRS(ctx, "EGM96 height", "EGM96 geoid", nil, "egm96_15.gtx")
+//
+func NewVerticalCRS ( ctx *Context, name string, datumName string, linear *Unit, geoidGrid string ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    lname, lfactor, e := unitParameter(ctx, "NewVerticalCRS", "linear", linear, LinearUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(lname))
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    cdatum := C.CString(datumName)
+    defer C.free(unsafe.Pointer(cdatum))
+    var cgrid *C.char
+    if geoidGrid != "" {
+        cgrid = C.CString(geoidGrid)
+        defer C.free(unsafe.Pointer(cgrid))
+    }
+    pj := C.proj_create_vertical_crs_ex((*ctx).pj, cname, cdatum, nil, nil, lname, lfactor, cgrid, nil, nil, nil, nil)
+    if pj == nil {
+        e = contextError(ctx, "NewVerticalCRS", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
+
+// NewCompoundCRS creates a compound reference system from a horizontal
+// (geographic 2D or projected) reference system and a vertical one :
+//
+//   wgs84, _ := NewReferenceSystem(ctx, "EPSG:4326")
+//   egm96, _ := NewVerticalCRS(ctx, "EGM96 height", "EGM96 geoid", nil, "egm96_15.gtx")
+//   crs, e := NewCompoundCRS(ctx, "WGS 84 + EGM96 height", wgs84, egm96)
+//
+func NewCompoundCRS ( ctx *Context, name string, horizontal *ReferenceSystem, vertical *ReferenceSystem ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    switch horizontal.TypeOf() {
+    case Geographic2DCRS, ProjectedCRS, EngineeringCRS, BoundCRS :
+    default :
+        e = newError(ctx, "NewCompoundCRS", name, ErrNotACRS, "Expected a horizontal CRS")
+        return
+    }
+    switch vertical.TypeOf() {
+    case VerticalCRS, BoundCRS :
+    default :
+        e = newError(ctx, "NewCompoundCRS", name, ErrNotACRS, "Expected a vertical CRS")
+        return
+    }
+    defer horizontal.use.enter(horizontal, (*horizontal).ctx, ctx)()
+    defer vertical.use.enter(vertical, (*vertical).ctx, ctx)()
+    defer runtime.KeepAlive(horizontal)
+    defer runtime.KeepAlive(vertical)
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    pj := C.proj_create_compound_crs((*ctx).pj, cname, (*horizontal).pj, (*vertical).pj)
+    if pj == nil {
+        e = contextError(ctx, "NewCompoundCRS", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
+
+// PromoteTo3D returns the 3D version of a geographic or projected 2D
+// reference system, the third axis being the ellipsoidal height in meters.
+// `name` is the name of the new reference system, empty to keep the name.
+//
+func (crs *ReferenceSystem) PromoteTo3D ( ctx *Context, name string ) ( c *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    switch crs.TypeOf() {
+    case Geographic2DCRS, ProjectedCRS :
+    default :
+        e = newError(ctx, "PromoteTo3D", crs.String(), ErrNotACRS, "Expected a geographic or projected CRS")
+        return
+    }
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    var cname *C.char
+    if name != "" {
+        cname = C.CString(name)
+        defer C.free(unsafe.Pointer(cname))
+    }
+    pj := C.proj_crs_promote_to_3D((*ctx).pj, cname, (*crs).pj)
+    if pj == nil {
+        e = contextError(ctx, "PromoteTo3D", crs.String(), ErrInvalidDefinition)
+        return
+    }
+    c = newReferenceSystem(ctx, pj)
+    return
+}
+
+// DemoteTo2D returns the 2D version of a geographic or projected 3D
+// reference system. `name` is the name of the new reference system, empty to
+// keep the name.
+//
+func (crs *ReferenceSystem) DemoteTo2D ( ctx *Context, name string ) ( c *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    switch crs.TypeOf() {
+    case Geographic3DCRS, ProjectedCRS :
+    default :
+        e = newError(ctx, "DemoteTo2D", crs.String(), ErrNotACRS, "Expected a geographic or projected CRS")
+        return
+    }
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    var cname *C.char
+    if name != "" {
+        cname = C.CString(name)
+        defer C.free(unsafe.Pointer(cname))
+    }
+    pj := C.proj_crs_demote_to_2D((*ctx).pj, cname, (*crs).pj)
+    if pj == nil {
+        e = contextError(ctx, "DemoteTo2D", crs.String(), ErrInvalidDefinition)
+        return
+    }
+    c = newReferenceSystem(ctx, pj)
+    return
+}
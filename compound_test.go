@@ -0,0 +1,97 @@
+package proj
+
+import (
+    "math"
+    "strings"
+    "testing"
+)
+
+// Tests :
+
+// TestCompoundCRS checks ellipsoidal heights are turned into EGM96 heights
+// through a compound CRS built with a geoid model.
+func TestCompoundCRS ( t *testing.T ) {
+    egm96, e := NewVerticalCRS(ctx, "EGM96 height", "EGM96 geoid", nil, "egm96_15.gtx")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer egm96.DestroyReferenceSystem()
+    if egm96.TypeOf() != VerticalCRS {
+        t.Errorf("Expected a vertical CRS, but got %v", egm96.TypeOf())
+    }
+    if wkt := egm96.Wkt(ctx, WKTv2r2018) ; !strings.Contains(wkt, `GEOIDMODEL["egm96_15.gtx"`) {
+        t.Errorf("Expected the geoid model in '%s'", wkt)
+    }
+    wgs84, e := NewReferenceSystem(ctx, "EPSG:4326")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer wgs84.DestroyReferenceSystem()
+    crs, e := NewCompoundCRS(ctx, "WGS 84 + EGM96 height", wgs84, egm96)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    if crs.TypeOf() != CompoundCRS {
+        t.Errorf("Expected a compound CRS, but got %v", crs.TypeOf())
+    }
+    if _, e = NewCompoundCRS(ctx, "bad", egm96, wgs84) ; e == nil {
+        t.Errorf("Expected an error when the vertical CRS comes first")
+    }
+    wgs84h, e := wgs84.PromoteTo3D(ctx, "")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer wgs84h.DestroyReferenceSystem()
+    op, e := wgs84h.NewOperation(ctx, crs)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer op.DestroyOperation()
+    r, e := op.Transform(Forward, NewCoordinate(0.0, 0.0, 0.0))
+    if e != nil {
+        t.Fatal(e)
+    }
+    if h := r.(*Coordinate).Z() ; math.Abs(h + 17.16) > 1.0 {
+        t.Errorf("Expected an EGM96 height near -17.16, but got %.2f", h)
+    }
+}
+
+// TestPromoteDemote checks 2D CRS are promoted to 3D and back.
+func TestPromoteDemote ( t *testing.T ) {
+    for _, c := range []struct {
+        def     string
+        typ2D   ISOType
+        typ3D   ISOType
+    }{
+        {"EPSG:4326", Geographic2DCRS, Geographic3DCRS},
+        {"EPSG:2154", ProjectedCRS, ProjectedCRS},
+    } {
+        crs, e := NewReferenceSystem(ctx, c.def)
+        if e != nil {
+            t.Fatal(e)
+        }
+        crs3D, e := crs.PromoteTo3D(ctx, "")
+        crs.DestroyReferenceSystem()
+        if e != nil {
+            t.Fatal(e)
+        }
+        cs, e := crs3D.CoordinateSystem(ctx)
+        if e != nil {
+            t.Fatal(e)
+        }
+        if axes, _ := cs.Axes(ctx) ; crs3D.TypeOf() != c.typ3D || len(axes) != 3 {
+            t.Errorf("Expected a 3D %v for %s, but got %v with %d axes", c.typ3D, c.def, crs3D.TypeOf(), len(axes))
+        }
+        cs.DestroyCoordinateSystem()
+        crs2D, e := crs3D.DemoteTo2D(ctx, "")
+        crs3D.DestroyReferenceSystem()
+        if e != nil {
+            t.Fatal(e)
+        }
+        if crs2D.TypeOf() != c.typ2D {
+            t.Errorf("Expected a %v for %s, but got %v", c.typ2D, c.def, crs2D.TypeOf())
+        }
+        crs2D.DestroyReferenceSystem()
+    }
+}
@@ -0,0 +1,89 @@
+package proj
+
+import (
+    "bytes"
+    "fmt"
+    "runtime"
+    "strconv"
+    "sync"
+    "sync/atomic"
+)
+
+var (
+    // concurrencyCheck is not 0 when the debug mode detecting concurrent use
+    // of PROJ objects is on
+    concurrencyCheck int32
+)
+
+// SetConcurrencyCheck turns on or off the debug mode that detects and
+// reports (through `Log()`) concurrent use of the same PROJ object from
+// different goroutines, as well as the use of an object through a context
+// that does not own it.
+// Off by default as it slows down every call.
+//
+func SetConcurrencyCheck ( on bool ) {
+    if on {
+        atomic.StoreInt32(&concurrencyCheck, 1)
+    } else {
+        atomic.StoreInt32(&concurrencyCheck, 0)
+    }
+}
+
+// ConcurrencyCheck returns true when the concurrent use debug mode is on.
+//
+func ConcurrencyCheck () bool {
+    return atomic.LoadInt32(&concurrencyCheck) != 0
+}
+
+// usage keeps track of the goroutine currently using a PROJ object.
+// Its zero value is ready to use.
+//
+// Internal use only.
+//
+type usage struct {
+    mu      sync.Mutex
+    gid     uint64  // goroutine using the object
+    depth   int     // re-entrance count of that goroutine
+}
+
+// enter records that the current goroutine uses the object `o` owned by
+// `owner` through `ctx` (nil when no context is involved). The returned
+// function must be called when the object is no longer used.
+// Does nothing when the debug mode is off.
+//
+func (u *usage) enter ( o interface{}, owner *Context, ctx *Context ) func () {
+    if !ConcurrencyCheck() {
+        return func () {}
+    }
+    gid := goroutineID()
+    if ctx != nil && owner != nil && ctx != owner {
+        LogOnError(fmt.Errorf("%T owned by context %p used through context %p in goroutine %d", o, owner, ctx, gid))
+    }
+    u.mu.Lock()
+    if u.depth > 0 && u.gid != gid {
+        LogOnError(fmt.Errorf("%T used concurrently by goroutines %d and %d", o, u.gid, gid))
+    } else {
+        u.gid = gid
+    }
+    u.depth++
+    u.mu.Unlock()
+    return func () {
+        u.mu.Lock()
+        u.depth--
+        u.mu.Unlock()
+    }
+}
+
+// goroutineID returns the identifier of the calling goroutine as found in
+// the first line of its stack trace ("goroutine 18 [running]:").
+//
+func goroutineID () uint64 {
+    var buf [64]byte
+    n := runtime.Stack(buf[:], false)
+    fields := bytes.Fields(buf[:n])
+    if len(fields) < 2 {
+        return 0
+    }
+    id, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+    return id
+}
@@ -0,0 +1,93 @@
+package proj
+
+import (
+    "testing"
+    "bytes"
+    "strings"
+)
+
+// Tests :
+
+// captureLog redirects the package logger into a buffer until the returned
+// function is called.
+func captureLog ( ) ( *bytes.Buffer, func () ) {
+    var buf bytes.Buffer
+    w := Log().Writer()
+    Log().SetOutput(&buf)
+    return &buf, func () { Log().SetOutput(w) }
+}
+
+// TestConcurrencyCheck checks the debug mode reporting concurrent use.
+func TestConcurrencyCheck ( t *testing.T ) {
+    buf, restore := captureLog()
+    defer restore()
+    var u usage
+    o := &Operation{}
+    leave := u.enter(o, nil, nil)
+    done := make(chan bool)
+    go func () { u.enter(o, nil, nil)() ; done <- true }()
+    <-done
+    leave()
+    if buf.Len() != 0 {
+        t.Errorf("Unexpected report when the debug mode is off : %s", buf.String())
+    }
+    SetConcurrencyCheck(true)
+    defer SetConcurrencyCheck(false)
+    if !ConcurrencyCheck() {
+        t.Errorf("Expected the debug mode to be on")
+    }
+    leave = u.enter(o, nil, nil)
+    u.enter(o, nil, nil)() // re-entrance in the same goroutine
+    if buf.Len() != 0 {
+        t.Errorf("Unexpected report for re-entrance : %s", buf.String())
+    }
+    go func () { u.enter(o, nil, nil)() ; done <- true }()
+    <-done
+    leave()
+    if !strings.Contains(buf.String(), "used concurrently") {
+        t.Errorf("Expected concurrent use to be reported, got '%s'", buf.String())
+    }
+    buf.Reset()
+    c := NewContext()
+    defer c.DestroyContext()
+    u.enter(o, ctx, c)()
+    if !strings.Contains(buf.String(), "used through context") {
+        t.Errorf("Expected foreign context use to be reported, got '%s'", buf.String())
+    }
+}
+
+// TestCloneIntoContext checks cloning into another context is not reported
+// as a foreign context use.
+func TestCloneIntoContext ( t *testing.T ) {
+    buf, restore := captureLog()
+    defer restore()
+    SetConcurrencyCheck(true)
+    defer SetConcurrencyCheck(false)
+    c := NewContext()
+    defer c.DestroyContext()
+    grs80, _ := GetEllipsoidEntryByID("GRS80")
+    ell, e := grs80.Ellipsoid(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer ell.DestroyEllipsoid()
+    cell, e := ell.Clone(c)
+    if e != nil {
+        t.Fatal(e)
+    }
+    cell.DestroyEllipsoid()
+    greenwich, _ := GetPrimeMeridianEntryByID("greenwich")
+    pm, e := greenwich.PrimeMeridian(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer pm.DestroyPrimeMeridian()
+    cpm, e := pm.Clone(c)
+    if e != nil {
+        t.Fatal(e)
+    }
+    cpm.DestroyPrimeMeridian()
+    if buf.Len() != 0 {
+        t.Errorf("Unexpected report when cloning : %s", buf.String())
+    }
+}
@@ -6,33 +6,164 @@ package proj
 #include "wrapper.h"
  */
 import "C"
-import "unsafe"
+
+import (
+    "io/fs"
+    "runtime"
+    "sort"
+    "unsafe"
+)
 
 // Context handles an internal threads context of the PROJ library
 //
 type Context struct {
-    pj *C.PJ_CONTEXT
+    pj          *C.PJ_CONTEXT
+    id          uintptr                         // identifier passed to the PROJ callbacks
+    searchPaths []string                        // paths given to SetSearchPaths
+    finder      func(string) (string, bool)     // file finder given to SetFileFinder
+    fsys        fs.FS                           // file system given to SetFileSystem
+    found       *C.char                         // last path returned to PROJ by the file finder
+    cacheDir    string                          // directory holding the files extracted by SetFileSystem
+    logger      LogHandler                      // handler given to SetLogger
+    capturing   int                             // depth of nested calls capturing error messages
+    userLevel   LoggingLevel                    // log level before capturing began
+    captured    []string                        // error messages captured during the current call
+    autoclose   bool                            // database closed after each use
+    authorities map[string]bool                 // authorities of the database, nil until looked up
+    auxPaths    []string                        // auxiliary databases
+    registry    map[string]map[string]*C.PJ     // CRS given to Register by authority and code
+    settings    uint64                          // number of changes of the settings
 }
 
-var (
-    authorities map[string]bool
-)
+// ContextOptions holds the settings applied to a context by
+// `NewContextWithOptions`.
+// Its zero value keeps PROJ defaults.
+//
+type ContextOptions struct {
+    // DatabasePath is the path to proj.db, empty for the default one
+    DatabasePath        string
+    // AuxiliaryDatabasePaths are the paths to auxiliary databases
+    AuxiliaryDatabasePaths []string
+    // SearchPaths are the directories where PROJ looks for resource files,
+    // nil for the default ones
+    SearchPaths         []string
+    // Logger receives the messages of the context, nil for
+    // `DefaultLogHandler`
+    Logger              LogHandler
+    // LogLevel is the log level of the context, nil to keep the level of
+    // PROJ
+    LogLevel            *LoggingLevel
+    // Proj4InitRules turns on or off the PROJ 4 compatibility mode for
+    // "+init=epsg:XXXX" definitions (see `SetProj4InitRules`), nil to let
+    // the PROJ_USE_PROJ4_INIT_RULES environment variable decide
+    Proj4InitRules      *bool
+    // AutoCloseDatabase closes the database after each use (see
+    // `SetAutoCloseDatabase`)
+    AutoCloseDatabase   bool
+}
+
+// clone returns a copy of the options sharing no slice or pointer with
+// them.
+//
+func (opts ContextOptions) clone () ContextOptions {
+    if opts.AuxiliaryDatabasePaths != nil {
+        opts.AuxiliaryDatabasePaths = append([]string(nil), opts.AuxiliaryDatabasePaths...)
+    }
+    if opts.SearchPaths != nil {
+        opts.SearchPaths = append([]string(nil), opts.SearchPaths...)
+    }
+    if opts.LogLevel != nil {
+        lvl := *opts.LogLevel
+        opts.LogLevel = &lvl
+    }
+    if opts.Proj4InitRules != nil {
+        on := *opts.Proj4InitRules
+        opts.Proj4InitRules = &on
+    }
+    return opts
+}
 
 // NewContext creates a new threading-context into the PROJ library.
 //
 func NewContext () (*Context) {
-    return &Context{pj:C.proj_context_create()}
+    ctx := &Context{pj:C.proj_context_create()}
+    allocated()
+    register(ctx)
+    SetLog(ctx)
+    runtime.SetFinalizer(ctx, func ( ctx *Context ) {
+        leaked(ctx)
+        ctx.DestroyContext()
+    })
+    return ctx
+}
+
+// NewContextWithOptions creates a new threading-context into the PROJ
+// library with the settings of `opts`. When a setting cannot be applied,
+// e.g. a wrong `DatabasePath`, the context is destroyed and the error is
+// returned :
+//
+//   lvl, on := Debug, true
+//   ctx, e := NewContextWithOptions(ContextOptions{SearchPaths:[]string{"/opt/proj"}, LogLevel:&lvl, Proj4InitRules:&on})
+//
+func NewContextWithOptions ( opts ContextOptions ) ( *Context, error ) {
+    ctx := NewContext()
+    if e := ctx.apply(opts) ; e != nil {
+        ctx.DestroyContext()
+        return nil, e
+    }
+    return ctx, nil
+}
+
+// apply assigns the settings to the context, search paths first as they
+// may locate the database.
+//
+func (ctx *Context) apply ( opts ContextOptions ) error {
+    if len(opts.SearchPaths) > 0 {
+        ctx.SetSearchPaths(opts.SearchPaths)
+    }
+    if opts.DatabasePath != "" || len(opts.AuxiliaryDatabasePaths) > 0 {
+        if e := ctx.SetDatabasePath(opts.DatabasePath, opts.AuxiliaryDatabasePaths...) ; e != nil {
+            return e
+        }
+    }
+    if opts.Logger != nil {
+        ctx.SetLogger(opts.Logger)
+    }
+    if opts.LogLevel != nil {
+        ctx.SetLogLevel(*opts.LogLevel)
+    }
+    if opts.Proj4InitRules != nil {
+        ctx.SetProj4InitRules(*opts.Proj4InitRules)
+    }
+    if opts.AutoCloseDatabase {
+        ctx.SetAutoCloseDatabase(true)
+    }
+    return nil
 }
 
 // DestroyContext deallocates the internal threading-context into the PROJ library.
 //
 func (ctx *Context) DestroyContext () {
     if (*ctx).pj != nil {
+        ctx.releaseRegistry()
         C.proj_context_destroy((*ctx).pj)
         (*ctx).pj = nil
+        unregister(ctx)
+        ctx.releaseFiles()
+        (*ctx).logger = nil
+        released()
+        runtime.SetFinalizer(ctx, nil)
     }
 }
 
+// Close deallocates the internal threading-context into the PROJ library.
+// It implements io.Closer.
+//
+func (ctx *Context) Close () error {
+    ctx.DestroyContext()
+    return nil
+}
+
 // Handle returns the PROJ internal object to be passed to the PROJ library
 //
 func (ctx *Context) Handle () (interface{}) {
@@ -48,50 +179,175 @@ func (ctx *Context) HandleIsNil () bool {
 // DatabasePath returns the path to the database, empty string if none.
 //
 func (ctx *Context) DatabasePath () string {
+    defer runtime.KeepAlive(ctx)
     p := C.proj_context_get_database_path((*ctx).pj)
     if p == nil { return "" }
     return C.GoString(p)
 }
 
-// SetDatabasePath assigns the path to the 'proj.db' file.
+// SetDatabasePath assigns the path to the 'proj.db' file, and optionally
+// the paths to auxiliary databases whose definitions are added to those of
+// 'proj.db' (see `CreateAuxiliaryDatabase`). On failure, PROJ keeps the
+// former databases.
 //
-func (ctx *Context) SetDatabasePath ( p string ) {
+func (ctx *Context) SetDatabasePath ( p string, aux ...string ) error {
+    if !ctx.setDatabasePath(p, aux) {
+        return contextError(ctx, "SetDatabasePath", p, nil)
+    }
+    return nil
+}
+
+// AuxiliaryDatabasePaths returns the paths to the auxiliary databases of
+// the context, nil if none.
+//
+func (ctx *Context) AuxiliaryDatabasePaths () []string {
+    if (*ctx).auxPaths == nil {
+        return nil
+    }
+    return append([]string(nil), (*ctx).auxPaths...)
+}
+
+// AddAuxiliaryDatabase adds an auxiliary database to the current database
+// of the context, e.g. one holding "MYCOMPANY:1001" :
+//
+//   e := ctx.AddAuxiliaryDatabase("/opt/mycompany/crs.db")
+//   crs, e := NewReferenceSystem(ctx, "MYCOMPANY:1001")
+//
+func (ctx *Context) AddAuxiliaryDatabase ( p string ) error {
+    aux := append(ctx.AuxiliaryDatabasePaths(), p)
+    if !ctx.setDatabasePath(ctx.DatabasePath(), aux) {
+        return contextError(ctx, "AddAuxiliaryDatabase", p, nil)
+    }
+    return nil
+}
+
+// setDatabasePath assigns the databases of the context, returns false on
+// failure, the former databases being kept.
+//
+func (ctx *Context) setDatabasePath ( p string, aux []string ) bool {
+    defer runtime.KeepAlive(ctx)
     dbp := C.CString(p)
     defer C.free(unsafe.Pointer(dbp))
-    _ = C.proj_context_set_database_path((*ctx).pj,dbp,nil,nil)
+    var caux **C.char
+    if l := len(aux) ; l > 0 {
+        caux = C.makeStringArray(C.size_t(l+1))
+        for i, a := range aux {
+            C.setStringArrayItem(caux, C.size_t(i), C.CString(a))
+        }
+        C.setStringArrayItem(caux, C.size_t(l), nil)
+        defer func () {
+            for i := 0 ; i < l ; i++ {
+                C.free(unsafe.Pointer(C.getStringArrayItem(caux, C.size_t(i))))
+            }
+            C.destroyStringArray(&caux)
+        }()
+    }
+    if C.proj_context_set_database_path((*ctx).pj, dbp, caux, nil) == C.int(0) {
+        return false
+    }
+    (*ctx).auxPaths = nil
+    if len(aux) > 0 {
+        (*ctx).auxPaths = append([]string(nil), aux...)
+    }
+    (*ctx).authorities = nil
+    (*ctx).settings++
+    return true
 }
 
-// IsAnAuthority checks whether the proposed name is an authority or not
+// Proj4InitRules returns true when "+init=epsg:XXXX" definitions follow the
+// PROJ 4 rules : axis order is longitude/latitude and units are those of
+// the proj-string, instead of the EPSG definition.
 //
-func (ctx *Context) IsAnAuthority ( name string ) bool {
-    return authorities[name]
+func (ctx *Context) Proj4InitRules () bool {
+    defer runtime.KeepAlive(ctx)
+    return C.proj_context_get_use_proj4_init_rules((*ctx).pj, C.int(0)) != C.int(0)
 }
 
-// LogLevel returns the current log level of PROJ.
+// SetProj4InitRules turns on or off the PROJ 4 compatibility mode for
+// "+init=epsg:XXXX" definitions.
 //
-func (ctx *Context) LogLevel ( ) LoggingLevel {
-    return LogLevel(ctx)
+func (ctx *Context) SetProj4InitRules ( on bool ) {
+    defer runtime.KeepAlive(ctx)
+    enable := C.int(0)
+    if on {
+        enable = C.int(1)
+    }
+    C.proj_context_use_proj4_init_rules((*ctx).pj, enable)
+    (*ctx).settings++
 }
 
-// SetLogLevel assigns the log level of PROJ.
+// AutoCloseDatabase returns true when the database is closed after each
+// use.
 //
-func (ctx *Context) SetLogLevel ( lvl LoggingLevel ) {
-    SetLogLevel(ctx,lvl)
+func (ctx *Context) AutoCloseDatabase () bool {
+    return (*ctx).autoclose
 }
 
-// init package initialisation
+// SetAutoCloseDatabase turns on or off the closing of the database after
+// each use, e.g. to let another process update it. Off by default.
 //
-func init () {
-    if auths := C.proj_get_authorities_from_database(nil) ; auths != nil {
-        authorities = make(map[string]bool)
-        for i := 0 ; i >= 0 ; i++ {
-            cauth := C.getAuthorityFromPROJ(auths, C.int(i))
-            if cauth == nil {
-                break
-            }
-            authorities[C.GoString(cauth)] = true
+func (ctx *Context) SetAutoCloseDatabase ( on bool ) {
+    defer runtime.KeepAlive(ctx)
+    autoclose := C.int(0)
+    if on {
+        autoclose = C.int(1)
+    }
+    C.proj_context_set_autoclose_database((*ctx).pj, autoclose)
+    (*ctx).autoclose = on
+    (*ctx).settings++
+}
+
+// IsAnAuthority checks whether the proposed name is an authority of the
+// database of the context, or of the CRS given to `Register`, or not
+//
+func (ctx *Context) IsAnAuthority ( name string ) bool {
+    return ctx.lookupAuthorities()[name] || (*ctx).registry[name] != nil
+}
+
+// Authorities returns the sorted names of the authorities of the database
+// of the context, e.g. "EPSG", "ESRI", "IGNF", "OGC", "PROJ", and of the CRS
+// given to `Register`.
+//
+func (ctx *Context) Authorities () []string {
+    auths := make([]string, 0, len(ctx.lookupAuthorities()) + len((*ctx).registry))
+    for a := range (*ctx).authorities {
+        auths = append(auths, a)
+    }
+    for a := range (*ctx).registry {
+        if !(*ctx).authorities[a] {
+            auths = append(auths, a)
+        }
+    }
+    sort.Strings(auths)
+    return auths
+}
+
+// lookupAuthorities returns the authorities of the database, read once
+// until the database changes.
+//
+func (ctx *Context) lookupAuthorities () map[string]bool {
+    if (*ctx).authorities != nil {
+        return (*ctx).authorities
+    }
+    defer runtime.KeepAlive(ctx)
+    (*ctx).authorities = make(map[string]bool)
+    if auths := C.proj_get_authorities_from_database((*ctx).pj) ; auths != nil {
+        for _, a := range goStrings(auths) {
+            (*ctx).authorities[a] = true
         }
         C.proj_string_list_destroy(auths)
     }
+    return (*ctx).authorities
+}
+
+// LogLevel returns the current log level of PROJ.
+//
+func (ctx *Context) LogLevel ( ) LoggingLevel {
+    return LogLevel(ctx)
 }
 
+// SetLogLevel assigns the log level of PROJ.
+//
+func (ctx *Context) SetLogLevel ( lvl LoggingLevel ) {
+    SetLogLevel(ctx,lvl)
+}
@@ -3,6 +3,7 @@ package proj
 import (
     "testing"
     "os"
+    "path/filepath"
     "reflect"
 )
 
@@ -10,19 +11,16 @@ import (
 
 // TestContext creates and destroy threading-context.
 func TestContext ( t *testing.T) {
-    pj := os.Getenv("PROJ_LIB")
-    os.Setenv("PROJ_LIB",os.TempDir()) // fake path to prevent retrieval of proj.db
     c := NewContext()
     if c.HandleIsNil() {
         t.Errorf("Failed to create a new threading-context")
     }
+    c.SetSearchPaths([]string{os.TempDir()}) // fake path to prevent retrieval of proj.db
     c.SetDatabasePath(os.TempDir()) // this should failed ... but PROJ keeps the previous path which is wrong too !
     if c.DatabasePath() != "" {
         t.Errorf("Unexpected database path %s for threading-context", c.DatabasePath())
     }
-    if pj != "" {
-        os.Setenv("PROJ_LIB",pj) // back to the right path ... 
-    }
+    c.SetSearchPaths(nil) // back to the right path ...
     if c.DatabasePath() == "" {
         t.Errorf("Expected database path for threading-context")
     }
@@ -74,3 +72,97 @@ func TestCtxLogging ( t *testing.T) {
     ctx.SetLogLevel(None)
 }
 
+
+// TestContextOptions checks settings are applied at creation.
+func TestContextOptions ( t *testing.T ) {
+    dir := os.TempDir()
+    lvl, on := Debug, true
+    c, e := NewContextWithOptions(ContextOptions{SearchPaths:[]string{dir}, LogLevel:&lvl, Proj4InitRules:&on, AutoCloseDatabase:true})
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyContext()
+    if paths := c.SearchPaths() ; len(paths) != 1 || paths[0] != dir {
+        t.Errorf("Expected search paths [%s], but got %v", dir, paths)
+    }
+    if c.LogLevel() != Debug {
+        t.Errorf("Expected log level %s, but got %s", Debug, c.LogLevel())
+    }
+    if !c.Proj4InitRules() {
+        t.Errorf("Expected PROJ 4 init rules to be on")
+    }
+    if !c.AutoCloseDatabase() {
+        t.Errorf("Expected database autoclose to be on")
+    }
+    c.SetProj4InitRules(false)
+    if c.Proj4InitRules() {
+        t.Errorf("Expected PROJ 4 init rules to be off")
+    }
+    c.SetAutoCloseDatabase(false)
+    if c.AutoCloseDatabase() {
+        t.Errorf("Expected database autoclose to be off")
+    }
+    off := false
+    d, e := NewContextWithOptions(ContextOptions{Proj4InitRules:&off})
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer d.DestroyContext()
+    if d.Proj4InitRules() {
+        t.Errorf("Expected PROJ 4 init rules to be turned off")
+    }
+    if _, e = NewContextWithOptions(ContextOptions{DatabasePath:filepath.Join(dir, "nowhere", "proj.db")}) ; e == nil {
+        t.Errorf("Expected an error for a missing database")
+    }
+}
+
+// TestContextOptionsLogLevel checks an unset log level keeps the one of
+// PROJ.
+func TestContextOptionsLogLevel ( t *testing.T ) {
+    d := NewContext()
+    defer d.DestroyContext()
+    c, e := NewContextWithOptions(ContextOptions{SearchPaths:[]string{os.TempDir()}})
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyContext()
+    if c.LogLevel() != d.LogLevel() {
+        t.Errorf("Expected log level %s, but got %s", d.LogLevel(), c.LogLevel())
+    }
+    z, e := NewContextWithOptions(ContextOptions{})
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer z.DestroyContext()
+    if z.LogLevel() != d.LogLevel() {
+        t.Errorf("Expected log level %s, but got %s", d.LogLevel(), z.LogLevel())
+    }
+}
+
+// TestAuthorities checks authorities follow the database of the context.
+func TestAuthorities ( t *testing.T ) {
+    auths := ctx.Authorities()
+    for _, a := range []string{"EPSG", "ESRI", "PROJ"} {
+        if !ctx.IsAnAuthority(a) {
+            t.Errorf("Expected '%s' to be an authority", a)
+        }
+    }
+    for i := 1 ; i < len(auths) ; i++ {
+        if auths[i-1] >= auths[i] {
+            t.Errorf("Expected sorted authorities, but got %v", auths)
+        }
+    }
+    c, e := NewContextWithOptions(ContextOptions{SearchPaths:[]string{os.TempDir()}})
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer c.DestroyContext()
+    c.SetDatabasePath(os.TempDir()) // no database there
+    if c.IsAnAuthority("EPSG") {
+        t.Errorf("Unexpected 'EPSG' authority without database")
+    }
+    c.SetSearchPaths(nil) // back to the default database
+    if !c.IsAnAuthority("EPSG") {
+        t.Errorf("Expected 'EPSG' authority once the database is back")
+    }
+}
@@ -0,0 +1,1364 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "fmt"
+    "math"
+    "runtime"
+    "unsafe"
+)
+
+// ConversionUnits holds the units in which the parameters of a conversion
+// are expressed.
+//
+type ConversionUnits struct {
+    // Angular is the unit of latitudes, longitudes and azimuths, nil for
+    // degree
+    Angular *Unit
+    // Linear is the unit of false eastings and northings, nil for meter
+    Linear  *Unit
+}
+
+// NaturalOriginParams holds the parameters of the projections defined at a
+// natural origin with a scale factor : transverse Mercator, Lambert conic
+// conformal (1SP), Mercator (variant A), stereographic.
+//
+type NaturalOriginParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the natural origin
+    Latitude        float64
+    // Longitude is the longitude of the natural origin (central meridian)
+    Longitude       float64
+    // Scale is the scale factor at the natural origin, 0 for 1
+    Scale           float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// CentreParams holds the parameters of the projections defined at a
+// natural origin without scale factor : Lambert azimuthal equal area,
+// Cassini-Soldner, orthographic, American polyconic.
+//
+type CentreParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the natural origin
+    Latitude        float64
+    // Longitude is the longitude of the natural origin
+    Longitude       float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// FalseOriginParams holds the parameters of the conic projections with two
+// standard parallels : Lambert conic conformal (2SP), Albers equal area.
+//
+type FalseOriginParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the false origin
+    Latitude        float64
+    // Longitude is the longitude of the false origin
+    Longitude       float64
+    // FirstParallel is the latitude of the first standard parallel
+    FirstParallel   float64
+    // SecondParallel is the latitude of the second standard parallel
+    SecondParallel  float64
+    // Easting is the easting at the false origin
+    Easting         float64
+    // Northing is the northing at the false origin
+    Northing        float64
+}
+
+// StandardParallelParams holds the parameters of the projections defined
+// by one standard parallel : Mercator (variant B), polar stereographic
+// (variant B), equidistant cylindrical.
+//
+type StandardParallelParams struct {
+    ConversionUnits
+    // StandardParallel is the latitude of the standard parallel
+    StandardParallel    float64
+    // Longitude is the longitude of origin
+    Longitude           float64
+    // FalseEasting is the false easting
+    FalseEasting        float64
+    // FalseNorthing is the false northing
+    FalseNorthing       float64
+}
+
+// HotineObliqueMercatorParams holds the parameters of the Hotine oblique
+// Mercator projections.
+//
+type HotineObliqueMercatorParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the projection centre
+    Latitude            float64
+    // Longitude is the longitude of the projection centre
+    Longitude           float64
+    // Azimuth is the azimuth of the initial line
+    Azimuth             float64
+    // RectifiedGridAngle is the angle from the rectified to the skew grid
+    RectifiedGridAngle  float64
+    // Scale is the scale factor on the initial line, 0 for 1
+    Scale               float64
+    // Easting is the false easting (variant A) or the easting at the
+    // projection centre (variant B)
+    Easting             float64
+    // Northing is the false northing (variant A) or the northing at the
+    // projection centre (variant B)
+    Northing            float64
+}
+
+// KrovakParams holds the parameters of the Krovak projections.
+//
+type KrovakParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the projection centre
+    Latitude                float64
+    // Longitude is the longitude of origin
+    Longitude               float64
+    // ColatitudeConeAxis is the co-latitude of the cone axis
+    ColatitudeConeAxis      float64
+    // PseudoStandardParallel is the latitude of the pseudo standard parallel
+    PseudoStandardParallel  float64
+    // Scale is the scale factor on the pseudo standard parallel, 0 for 1
+    Scale                   float64
+    // FalseEasting is the false easting
+    FalseEasting            float64
+    // FalseNorthing is the false northing
+    FalseNorthing           float64
+}
+
+// CentralMeridianParams holds the parameters of the world projections
+// defined by their central meridian : Eckert, Wagner, Mollweide, Robinson,
+// sinusoidal, etc.
+//
+type CentralMeridianParams struct {
+    ConversionUnits
+    // Longitude is the longitude of the central meridian
+    Longitude       float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// InternationalMapWorldPolyconicParams holds the parameters of the
+// International Map of the World polyconic projection.
+//
+type InternationalMapWorldPolyconicParams struct {
+    ConversionUnits
+    // Longitude is the longitude of the central meridian
+    Longitude       float64
+    // FirstParallel is the latitude of the first standard parallel
+    FirstParallel   float64
+    // SecondParallel is the latitude of the second standard parallel
+    SecondParallel  float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// GeostationarySatelliteParams holds the parameters of the geostationary
+// satellite views.
+//
+type GeostationarySatelliteParams struct {
+    ConversionUnits
+    // Longitude is the longitude of the sub-satellite point
+    Longitude       float64
+    // Height is the height of the satellite above the ellipsoid
+    Height          float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// LabordeObliqueMercatorParams holds the parameters of the Laborde oblique
+// Mercator projection.
+//
+type LabordeObliqueMercatorParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the projection centre
+    Latitude        float64
+    // Longitude is the longitude of the projection centre
+    Longitude       float64
+    // Azimuth is the azimuth of the initial line
+    Azimuth         float64
+    // Scale is the scale factor on the initial line, 0 for 1
+    Scale           float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// HotineObliqueMercatorTwoPointParams holds the parameters of the Hotine
+// oblique Mercator projection whose initial line is defined by two points.
+//
+type HotineObliqueMercatorTwoPointParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the projection centre
+    Latitude        float64
+    // FirstLatitude is the latitude of the first point
+    FirstLatitude   float64
+    // FirstLongitude is the longitude of the first point
+    FirstLongitude  float64
+    // SecondLatitude is the latitude of the second point
+    SecondLatitude  float64
+    // SecondLongitude is the longitude of the second point
+    SecondLongitude float64
+    // Scale is the scale factor on the initial line, 0 for 1
+    Scale           float64
+    // Easting is the easting at the projection centre
+    Easting         float64
+    // Northing is the northing at the projection centre
+    Northing        float64
+}
+
+// TwoPointEquidistantParams holds the parameters of the two point
+// equidistant projection.
+//
+type TwoPointEquidistantParams struct {
+    ConversionUnits
+    // FirstLatitude is the latitude of the first point
+    FirstLatitude   float64
+    // FirstLongitude is the longitude of the first point
+    FirstLongitude  float64
+    // SecondLatitude is the latitude of the second point
+    SecondLatitude  float64
+    // SecondLongitude is the longitude of the second point
+    SecondLongitude float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// VerticalPerspectiveParams holds the parameters of the vertical
+// perspective projection.
+//
+type VerticalPerspectiveParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the topocentric origin
+    Latitude        float64
+    // Longitude is the longitude of the topocentric origin
+    Longitude       float64
+    // Height is the ellipsoidal height of the topocentric origin
+    Height          float64
+    // ViewPointHeight is the height of the view point above the
+    // topocentric origin
+    ViewPointHeight float64
+    // FalseEasting is the false easting
+    FalseEasting    float64
+    // FalseNorthing is the false northing
+    FalseNorthing   float64
+}
+
+// SphericalCrossTrackHeightParams holds the parameters of the spherical
+// cross-track height conversion, defined at a peg point.
+//
+type SphericalCrossTrackHeightParams struct {
+    ConversionUnits
+    // Latitude is the latitude of the peg point
+    Latitude        float64
+    // Longitude is the longitude of the peg point
+    Longitude       float64
+    // Heading is the heading at the peg point
+    Heading         float64
+    // Height is the height of the peg point
+    Height          float64
+}
+
+// PoleRotationParams holds the parameters of the pole rotation (GRIB
+// convention). The linear unit is not used.
+//
+type PoleRotationParams struct {
+    ConversionUnits
+    // SouthPoleLatitude is the latitude of the southern pole in the
+    // unrotated CRS
+    SouthPoleLatitude   float64
+    // SouthPoleLongitude is the longitude of the southern pole in the
+    // unrotated CRS
+    SouthPoleLongitude  float64
+    // AxisRotation is the angle of rotation about the new polar axis
+    AxisRotation        float64
+}
+
+// conversionBuilder checks the parameters of a conversion before creating
+// it. The first failed check is kept.
+//
+type conversionBuilder struct {
+    ctx     *Context
+    op      string
+    units   ConversionUnits
+    e       error
+}
+
+// fail records the first failed check.
+//
+func (b *conversionBuilder) fail ( param string, msg string ) {
+    if (*b).e == nil {
+        (*b).e = newError((*b).ctx, (*b).op, param, ErrInvalidParameter, msg)
+    }
+}
+
+// degrees converts an angle into degrees. The angular unit is checked by
+// `angle` beforehand.
+//
+func (b *conversionBuilder) degrees ( v float64 ) float64 {
+    if (*b).units.Angular == nil {
+        return v
+    }
+    return v * (*b).units.Angular.ToSI() * 180.0 / math.Pi
+}
+
+// value checks the parameter is a finite number.
+//
+func (b *conversionBuilder) value ( param string, v float64 ) float64 {
+    if math.IsNaN(v) || math.IsInf(v, 0) {
+        b.fail(param, fmt.Sprintf("Expected a finite value, but got %v", v))
+    }
+    return v
+}
+
+// angle checks the angular unit is one, then the parameter is an angle
+// within [-limit, limit] degrees.
+//
+func (b *conversionBuilder) angle ( param string, v float64, limit float64 ) float64 {
+    b.value(param, v)
+    if u := (*b).units.Angular ; u != nil && u.Category() != AngularUnit {
+        b.fail("Angular", fmt.Sprintf("Expected an angular unit, but got %s unit '%s'", u.Category(), u.Name()))
+        return v
+    }
+    if d := b.degrees(v) ; math.Abs(d) > limit {
+        b.fail(param, fmt.Sprintf("Expected a value within [-%g, %g] degrees, but got %v", limit, limit, v))
+    }
+    return v
+}
+
+// latitude checks the parameter is a latitude.
+//
+func (b *conversionBuilder) latitude ( param string, v float64 ) float64 {
+    return b.angle(param, v, 90.0)
+}
+
+// longitude checks the parameter is a longitude.
+//
+func (b *conversionBuilder) longitude ( param string, v float64 ) float64 {
+    return b.angle(param, v, 360.0)
+}
+
+// positive checks the parameter is a positive number.
+//
+func (b *conversionBuilder) positive ( param string, v float64 ) float64 {
+    if !(b.value(param, v) > 0.0) {
+        b.fail(param, fmt.Sprintf("Expected a positive value, but got %v", v))
+    }
+    return v
+}
+
+// scale checks the parameter is a positive scale factor, 0 standing for 1.
+//
+func (b *conversionBuilder) scale ( param string, v float64 ) float64 {
+    if v == 0.0 {
+        return 1.0
+    }
+    if b.value(param, v) < 0.0 {
+        b.fail(param, fmt.Sprintf("Expected a positive scale factor, but got %v", v))
+    }
+    return v
+}
+
+// build creates the conversion with `create` when every check passed.
+//
+func (b *conversionBuilder) build ( create func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ ) ( *Operation, error ) {
+    if (*b).e != nil {
+        return nil, (*b).e
+    }
+    ctx := (*b).ctx
+    defer runtime.KeepAlive(ctx)
+    ang, angf, e := unitParameter(ctx, (*b).op, "Angular", (*b).units.Angular, AngularUnit)
+    if e != nil {
+        return nil, e
+    }
+    defer C.free(unsafe.Pointer(ang))
+    lin, linf, e := unitParameter(ctx, (*b).op, "Linear", (*b).units.Linear, LinearUnit)
+    if e != nil {
+        return nil, e
+    }
+    defer C.free(unsafe.Pointer(lin))
+    pj := create(ang, angf, lin, linf)
+    if pj == nil {
+        return nil, contextError(ctx, (*b).op, "", ErrInvalidParameter)
+    }
+    return newOperation(ctx, pj), nil
+}
+
+// NewUTMConversion creates the Universal Transverse Mercator conversion of
+// a zone, from 1 to 60.
+//
+func NewUTMConversion ( ctx *Context, zone int, north bool ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewUTMConversion"}
+    if zone < 1 || zone > 60 {
+        b.fail("zone", fmt.Sprintf("Expected a zone within [1, 60], but got %d", zone))
+    }
+    var n C.int
+    if north {
+        n = 1
+    }
+    return b.build(func ( _ *C.char, _ C.double, _ *C.char, _ C.double ) *C.PJ {
+        return C.proj_create_conversion_utm((*ctx).pj, C.int(zone), n)
+    })
+}
+
+// naturalOrigin checks the parameters of a projection defined at a natural
+// origin with a scale factor.
+//
+func naturalOrigin ( ctx *Context, op string, p *NaturalOriginParams ) ( *conversionBuilder, [5]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [5]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.scale("Scale", (*p).Scale)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+}
+
+// NewTransverseMercatorConversion creates a transverse Mercator conversion,
+// e.g. the one of the German Gauss-Krüger zone 3 :
+//
+//   op, e := NewTransverseMercatorConversion(ctx, &NaturalOriginParams{Longitude:9, Scale:1, FalseEasting:3500000})
+//
+func NewTransverseMercatorConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewTransverseMercatorConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_transverse_mercator((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewGaussSchreiberTransverseMercatorConversion creates a Gauss-Schreiber
+// transverse Mercator conversion.
+//
+func NewGaussSchreiberTransverseMercatorConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewGaussSchreiberTransverseMercatorConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_gauss_schreiber_transverse_mercator((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewTransverseMercatorSouthOrientedConversion creates a transverse Mercator
+// (south oriented) conversion.
+//
+func NewTransverseMercatorSouthOrientedConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewTransverseMercatorSouthOrientedConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_transverse_mercator_south_oriented((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewLambertConicConformal1SPConversion creates a Lambert conic conformal
+// conversion with one standard parallel, the latitude of natural origin.
+//
+func NewLambertConicConformal1SPConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewLambertConicConformal1SPConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_conic_conformal_1sp((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewMercatorVariantAConversion creates a Mercator (variant A) conversion,
+// the latitude of natural origin being 0.
+//
+func NewMercatorVariantAConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewMercatorVariantAConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_mercator_variant_a((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewObliqueStereographicConversion creates an oblique stereographic
+// conversion.
+//
+func NewObliqueStereographicConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewObliqueStereographicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_oblique_stereographic((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewStereographicConversion creates a stereographic conversion.
+//
+func NewStereographicConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewStereographicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_stereographic((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// NewPolarStereographicVariantAConversion creates a polar stereographic
+// (variant A) conversion, the latitude of natural origin being 90 or -90
+// degrees.
+//
+func NewPolarStereographicVariantAConversion ( ctx *Context, p *NaturalOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := naturalOrigin(ctx, "NewPolarStereographicVariantAConversion", p)
+    if d := b.degrees((*p).Latitude) ; math.Abs(math.Abs(d) - 90.0) > 1e-9 {
+        b.fail("Latitude", fmt.Sprintf("Expected a pole, but got %v", (*p).Latitude))
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_polar_stereographic_variant_a((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// centre checks the parameters of a projection defined at a natural origin
+// without scale factor.
+//
+func centre ( ctx *Context, op string, p *CentreParams ) ( *conversionBuilder, [4]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [4]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+}
+
+// NewLambertAzimuthalEqualAreaConversion creates a Lambert azimuthal equal
+// area conversion, e.g. the one of ETRS89-LAEA :
+//
+//   op, e := NewLambertAzimuthalEqualAreaConversion(ctx, &CentreParams{Latitude:52, Longitude:10, FalseEasting:4321000, FalseNorthing:3210000})
+//
+func NewLambertAzimuthalEqualAreaConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewLambertAzimuthalEqualAreaConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_azimuthal_equal_area((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewCassiniSoldnerConversion creates a Cassini-Soldner conversion.
+//
+func NewCassiniSoldnerConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewCassiniSoldnerConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_cassini_soldner((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewOrthographicConversion creates an orthographic conversion.
+//
+func NewOrthographicConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewOrthographicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_orthographic((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewAmericanPolyconicConversion creates an American polyconic conversion.
+//
+func NewAmericanPolyconicConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewAmericanPolyconicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_american_polyconic((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewAzimuthalEquidistantConversion creates an azimuthal equidistant
+// conversion.
+//
+func NewAzimuthalEquidistantConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewAzimuthalEquidistantConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_azimuthal_equidistant((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewBonneConversion creates a Bonne conversion, the latitude of natural
+// origin being the standard parallel.
+//
+func NewBonneConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewBonneConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_bonne((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewGnomonicConversion creates a gnomonic conversion.
+//
+func NewGnomonicConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewGnomonicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_gnomonic((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewGuamConversion creates a Guam projection conversion.
+//
+func NewGuamConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewGuamConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_guam_projection((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewNewZealandMappingGridConversion creates a New Zealand map grid
+// conversion.
+//
+func NewNewZealandMappingGridConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewNewZealandMappingGridConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_new_zealand_mapping_grid((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewPopularVisualisationPseudoMercatorConversion creates the popular
+// visualisation pseudo Mercator conversion, e.g. the one of Web Mercator :
+//
+//   op, e := NewPopularVisualisationPseudoMercatorConversion(ctx, &CentreParams{})
+//
+func NewPopularVisualisationPseudoMercatorConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewPopularVisualisationPseudoMercatorConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_popular_visualisation_pseudo_mercator((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewQuadrilateralizedSphericalCubeConversion creates a
+// quadrilateralized spherical cube conversion.
+//
+func NewQuadrilateralizedSphericalCubeConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewQuadrilateralizedSphericalCubeConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_quadrilateralized_spherical_cube((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewTunisiaMappingGridConversion creates a Tunisia mapping grid conversion.
+//
+func NewTunisiaMappingGridConversion ( ctx *Context, p *CentreParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centre(ctx, "NewTunisiaMappingGridConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_tunisia_mapping_grid((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// falseOrigin checks the parameters of a conic projection with two standard
+// parallels.
+//
+func falseOrigin ( ctx *Context, op string, p *FalseOriginParams ) ( *conversionBuilder, [6]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    v := [6]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.latitude("FirstParallel", (*p).FirstParallel)),
+        C.double(b.latitude("SecondParallel", (*p).SecondParallel)),
+        C.double(b.value("Easting", (*p).Easting)),
+        C.double(b.value("Northing", (*p).Northing)),
+    }
+    if (*p).FirstParallel == -(*p).SecondParallel {
+        b.fail("SecondParallel", "Expected standard parallels not symmetric about the equator")
+    }
+    return b, v
+}
+
+// NewLambertConicConformal2SPConversion creates a Lambert conic conformal
+// conversion with two standard parallels, e.g. the one of Lambert-93 :
+//
+//   op, e := NewLambertConicConformal2SPConversion(ctx, &FalseOriginParams{
+//       Latitude:46.5, Longitude:3, FirstParallel:49, SecondParallel:44,
+//       Easting:700000, Northing:6600000,
+//   })
+//
+func NewLambertConicConformal2SPConversion ( ctx *Context, p *FalseOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := falseOrigin(ctx, "NewLambertConicConformal2SPConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_conic_conformal_2sp((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewLambertConicConformal2SPBelgiumConversion creates a Lambert conic
+// conformal (2SP Belgium) conversion.
+//
+func NewLambertConicConformal2SPBelgiumConversion ( ctx *Context, p *FalseOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := falseOrigin(ctx, "NewLambertConicConformal2SPBelgiumConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_conic_conformal_2sp_belgium((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewAlbersEqualAreaConversion creates an Albers equal area conversion.
+//
+func NewAlbersEqualAreaConversion ( ctx *Context, p *FalseOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := falseOrigin(ctx, "NewAlbersEqualAreaConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_albers_equal_area((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewEquidistantConicConversion creates an equidistant conic conversion, the
+// false origin being the centre of the projection.
+//
+func NewEquidistantConicConversion ( ctx *Context, p *FalseOriginParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := falseOrigin(ctx, "NewEquidistantConicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_equidistant_conic((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewLambertConicConformal2SPMichiganConversion creates a Lambert conic
+// conformal (2SP Michigan) conversion, the ellipsoid being scaled by
+// `ellipsoidScale`.
+//
+func NewLambertConicConformal2SPMichiganConversion ( ctx *Context, p *FalseOriginParams, ellipsoidScale float64 ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := falseOrigin(ctx, "NewLambertConicConformal2SPMichiganConversion", p)
+    k := C.double(b.scale("ellipsoidScale", ellipsoidScale))
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_conic_conformal_2sp_michigan((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], k, ang, angf, lin, linf)
+    })
+}
+
+// standardParallel checks the parameters of a projection defined by one
+// standard parallel.
+//
+func standardParallel ( ctx *Context, op string, p *StandardParallelParams ) ( *conversionBuilder, [4]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [4]C.double{
+        C.double(b.latitude("StandardParallel", (*p).StandardParallel)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+}
+
+// NewMercatorVariantBConversion creates a Mercator (variant B) conversion.
+//
+func NewMercatorVariantBConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewMercatorVariantBConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_mercator_variant_b((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewPolarStereographicVariantBConversion creates a polar stereographic
+// (variant B) conversion.
+//
+func NewPolarStereographicVariantBConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewPolarStereographicVariantBConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_polar_stereographic_variant_b((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewEquidistantCylindricalConversion creates an equidistant cylindrical
+// conversion.
+//
+func NewEquidistantCylindricalConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewEquidistantCylindricalConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_equidistant_cylindrical((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewEquidistantCylindricalSphericalConversion creates an equidistant
+// cylindrical (spherical) conversion.
+//
+func NewEquidistantCylindricalSphericalConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewEquidistantCylindricalSphericalConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_equidistant_cylindrical_spherical((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewLambertCylindricalEqualAreaConversion creates a Lambert cylindrical
+// equal area conversion.
+//
+func NewLambertCylindricalEqualAreaConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewLambertCylindricalEqualAreaConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_cylindrical_equal_area((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewLambertCylindricalEqualAreaSphericalConversion creates a Lambert
+// cylindrical equal area (spherical) conversion.
+//
+func NewLambertCylindricalEqualAreaSphericalConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewLambertCylindricalEqualAreaSphericalConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_lambert_cylindrical_equal_area_spherical((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerIIIConversion creates a Wagner III conversion, the standard
+// parallel being the latitude of true scale.
+//
+func NewWagnerIIIConversion ( ctx *Context, p *StandardParallelParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := standardParallel(ctx, "NewWagnerIIIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_iii((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// hotine checks the parameters of a Hotine oblique Mercator projection.
+//
+func hotine ( ctx *Context, op string, p *HotineObliqueMercatorParams ) ( *conversionBuilder, [7]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [7]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.angle("Azimuth", (*p).Azimuth, 360.0)),
+        C.double(b.angle("RectifiedGridAngle", (*p).RectifiedGridAngle, 360.0)),
+        C.double(b.scale("Scale", (*p).Scale)),
+        C.double(b.value("Easting", (*p).Easting)),
+        C.double(b.value("Northing", (*p).Northing)),
+    }
+}
+
+// NewHotineObliqueMercatorVariantAConversion creates a Hotine oblique
+// Mercator (variant A) conversion, with false easting and northing at the
+// natural origin.
+//
+func NewHotineObliqueMercatorVariantAConversion ( ctx *Context, p *HotineObliqueMercatorParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := hotine(ctx, "NewHotineObliqueMercatorVariantAConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_hotine_oblique_mercator_variant_a((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], v[6], ang, angf, lin, linf)
+    })
+}
+
+// NewHotineObliqueMercatorVariantBConversion creates a Hotine oblique
+// Mercator (variant B) conversion, with easting and northing at the
+// projection centre.
+//
+func NewHotineObliqueMercatorVariantBConversion ( ctx *Context, p *HotineObliqueMercatorParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := hotine(ctx, "NewHotineObliqueMercatorVariantBConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_hotine_oblique_mercator_variant_b((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], v[6], ang, angf, lin, linf)
+    })
+}
+
+// krovak checks the parameters of a Krovak projection.
+//
+func krovak ( ctx *Context, op string, p *KrovakParams ) ( *conversionBuilder, [7]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [7]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.latitude("ColatitudeConeAxis", (*p).ColatitudeConeAxis)),
+        C.double(b.latitude("PseudoStandardParallel", (*p).PseudoStandardParallel)),
+        C.double(b.scale("Scale", (*p).Scale)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+}
+
+// NewKrovakConversion creates a Krovak conversion (south-west axes).
+//
+func NewKrovakConversion ( ctx *Context, p *KrovakParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := krovak(ctx, "NewKrovakConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_krovak((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], v[6], ang, angf, lin, linf)
+    })
+}
+
+// NewKrovakNorthOrientedConversion creates a Krovak (north oriented)
+// conversion.
+//
+func NewKrovakNorthOrientedConversion ( ctx *Context, p *KrovakParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := krovak(ctx, "NewKrovakNorthOrientedConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_krovak_north_oriented((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], v[6], ang, angf, lin, linf)
+    })
+}
+
+// centralMeridian checks the parameters of a world projection defined by its
+// central meridian.
+//
+func centralMeridian ( ctx *Context, op string, p *CentralMeridianParams ) ( *conversionBuilder, [3]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [3]C.double{
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+}
+
+// NewEckertIConversion creates an Eckert I conversion.
+//
+func NewEckertIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEckertIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_eckert_i((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewEckertIIConversion creates an Eckert II conversion.
+//
+func NewEckertIIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEckertIIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_eckert_ii((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewEckertIIIConversion creates an Eckert III conversion.
+//
+func NewEckertIIIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEckertIIIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_eckert_iii((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewEckertIVConversion creates an Eckert IV conversion.
+//
+func NewEckertIVConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEckertIVConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_eckert_iv((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewEckertVConversion creates an Eckert V conversion.
+//
+func NewEckertVConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEckertVConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_eckert_v((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewEckertVIConversion creates an Eckert VI conversion.
+//
+func NewEckertVIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEckertVIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_eckert_vi((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewEqualEarthConversion creates an Equal Earth conversion.
+//
+func NewEqualEarthConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewEqualEarthConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_equal_earth((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewGallStereographicConversion creates a Gall stereographic conversion.
+//
+func NewGallStereographicConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewGallStereographicConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_gall((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewGoodeHomolosineConversion creates a Goode homolosine conversion.
+//
+func NewGoodeHomolosineConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewGoodeHomolosineConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_goode_homolosine((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewInterruptedGoodeHomolosineConversion creates an interrupted Goode homolosine
+// conversion.
+//
+func NewInterruptedGoodeHomolosineConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewInterruptedGoodeHomolosineConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_interrupted_goode_homolosine((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewMillerCylindricalConversion creates a Miller cylindrical conversion.
+//
+func NewMillerCylindricalConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewMillerCylindricalConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_miller_cylindrical((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewMollweideConversion creates a Mollweide conversion.
+//
+func NewMollweideConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewMollweideConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_mollweide((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewRobinsonConversion creates a Robinson conversion.
+//
+func NewRobinsonConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewRobinsonConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_robinson((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewSinusoidalConversion creates a sinusoidal conversion.
+//
+func NewSinusoidalConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewSinusoidalConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_sinusoidal((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewVanDerGrintenConversion creates a Van der Grinten conversion.
+//
+func NewVanDerGrintenConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewVanDerGrintenConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_van_der_grinten((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerIConversion creates a Wagner I conversion.
+//
+func NewWagnerIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewWagnerIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_i((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerIIConversion creates a Wagner II conversion.
+//
+func NewWagnerIIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewWagnerIIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_ii((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerIVConversion creates a Wagner IV conversion.
+//
+func NewWagnerIVConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewWagnerIVConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_iv((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerVConversion creates a Wagner V conversion.
+//
+func NewWagnerVConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewWagnerVConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_v((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerVIConversion creates a Wagner VI conversion.
+//
+func NewWagnerVIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewWagnerVIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_vi((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewWagnerVIIConversion creates a Wagner VII conversion.
+//
+func NewWagnerVIIConversion ( ctx *Context, p *CentralMeridianParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := centralMeridian(ctx, "NewWagnerVIIConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_wagner_vii((*ctx).pj, v[0], v[1], v[2], ang, angf, lin, linf)
+    })
+}
+
+// NewInternationalMapWorldPolyconicConversion creates an International Map
+// of the World polyconic conversion.
+//
+func NewInternationalMapWorldPolyconicConversion ( ctx *Context, p *InternationalMapWorldPolyconicParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewInternationalMapWorldPolyconicConversion", units:(*p).ConversionUnits}
+    v := [5]C.double{
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.latitude("FirstParallel", (*p).FirstParallel)),
+        C.double(b.latitude("SecondParallel", (*p).SecondParallel)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_international_map_world_polyconic((*ctx).pj, v[0], v[1], v[2], v[3], v[4], ang, angf, lin, linf)
+    })
+}
+
+// geostationarySatellite checks the parameters of a geostationary satellite
+// view.
+//
+func geostationarySatellite ( ctx *Context, op string, p *GeostationarySatelliteParams ) ( *conversionBuilder, [4]C.double ) {
+    b := &conversionBuilder{ctx:ctx, op:op, units:(*p).ConversionUnits}
+    return b, [4]C.double{
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.positive("Height", (*p).Height)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+}
+
+// NewGeostationarySatelliteSweepXConversion creates a geostationary
+// satellite view conversion whose sweep angle axis is X, e.g. the one of
+// GOES-R :
+//
+//   op, e := NewGeostationarySatelliteSweepXConversion(ctx, &GeostationarySatelliteParams{Longitude:-75, Height:35786023})
+//
+func NewGeostationarySatelliteSweepXConversion ( ctx *Context, p *GeostationarySatelliteParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := geostationarySatellite(ctx, "NewGeostationarySatelliteSweepXConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_geostationary_satellite_sweep_x((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewGeostationarySatelliteSweepYConversion creates a geostationary
+// satellite view conversion whose sweep angle axis is Y, e.g. the one of
+// Meteosat.
+//
+func NewGeostationarySatelliteSweepYConversion ( ctx *Context, p *GeostationarySatelliteParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b, v := geostationarySatellite(ctx, "NewGeostationarySatelliteSweepYConversion", p)
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_geostationary_satellite_sweep_y((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewLabordeObliqueMercatorConversion creates a Laborde oblique Mercator
+// conversion.
+//
+func NewLabordeObliqueMercatorConversion ( ctx *Context, p *LabordeObliqueMercatorParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewLabordeObliqueMercatorConversion", units:(*p).ConversionUnits}
+    v := [6]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.angle("Azimuth", (*p).Azimuth, 360.0)),
+        C.double(b.scale("Scale", (*p).Scale)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_laborde_oblique_mercator((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewHotineObliqueMercatorTwoPointConversion creates a Hotine oblique
+// Mercator conversion whose initial line goes through two points, with
+// easting and northing at the projection centre.
+//
+func NewHotineObliqueMercatorTwoPointConversion ( ctx *Context, p *HotineObliqueMercatorTwoPointParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewHotineObliqueMercatorTwoPointConversion", units:(*p).ConversionUnits}
+    v := [8]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.latitude("FirstLatitude", (*p).FirstLatitude)),
+        C.double(b.longitude("FirstLongitude", (*p).FirstLongitude)),
+        C.double(b.latitude("SecondLatitude", (*p).SecondLatitude)),
+        C.double(b.longitude("SecondLongitude", (*p).SecondLongitude)),
+        C.double(b.scale("Scale", (*p).Scale)),
+        C.double(b.value("Easting", (*p).Easting)),
+        C.double(b.value("Northing", (*p).Northing)),
+    }
+    if (*p).FirstLatitude == (*p).SecondLatitude && (*p).FirstLongitude == (*p).SecondLongitude {
+        b.fail("SecondLatitude", "Expected two distinct points")
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_hotine_oblique_mercator_two_point_natural_origin((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], v[6], v[7], ang, angf, lin, linf)
+    })
+}
+
+// NewTwoPointEquidistantConversion creates a two point equidistant
+// conversion.
+//
+func NewTwoPointEquidistantConversion ( ctx *Context, p *TwoPointEquidistantParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewTwoPointEquidistantConversion", units:(*p).ConversionUnits}
+    v := [6]C.double{
+        C.double(b.latitude("FirstLatitude", (*p).FirstLatitude)),
+        C.double(b.longitude("FirstLongitude", (*p).FirstLongitude)),
+        C.double(b.latitude("SecondLatitude", (*p).SecondLatitude)),
+        C.double(b.longitude("SecondLongitude", (*p).SecondLongitude)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+    if (*p).FirstLatitude == (*p).SecondLatitude && (*p).FirstLongitude == (*p).SecondLongitude {
+        b.fail("SecondLatitude", "Expected two distinct points")
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_two_point_equidistant((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewVerticalPerspectiveConversion creates a vertical perspective
+// conversion.
+//
+func NewVerticalPerspectiveConversion ( ctx *Context, p *VerticalPerspectiveParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewVerticalPerspectiveConversion", units:(*p).ConversionUnits}
+    v := [6]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.value("Height", (*p).Height)),
+        C.double(b.positive("ViewPointHeight", (*p).ViewPointHeight)),
+        C.double(b.value("FalseEasting", (*p).FalseEasting)),
+        C.double(b.value("FalseNorthing", (*p).FalseNorthing)),
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_vertical_perspective((*ctx).pj, v[0], v[1], v[2], v[3], v[4], v[5], ang, angf, lin, linf)
+    })
+}
+
+// NewSphericalCrossTrackHeightConversion creates a spherical cross-track
+// height conversion.
+//
+func NewSphericalCrossTrackHeightConversion ( ctx *Context, p *SphericalCrossTrackHeightParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewSphericalCrossTrackHeightConversion", units:(*p).ConversionUnits}
+    v := [4]C.double{
+        C.double(b.latitude("Latitude", (*p).Latitude)),
+        C.double(b.longitude("Longitude", (*p).Longitude)),
+        C.double(b.angle("Heading", (*p).Heading, 360.0)),
+        C.double(b.value("Height", (*p).Height)),
+    }
+    return b.build(func ( ang *C.char, angf C.double, lin *C.char, linf C.double ) *C.PJ {
+        return C.proj_create_conversion_spherical_cross_track_height((*ctx).pj, v[0], v[1], v[2], v[3], ang, angf, lin, linf)
+    })
+}
+
+// NewPoleRotationGRIBConversion creates a pole rotation conversion (GRIB
+// convention), e.g. for rotated latitude/longitude grids of weather models :
+//
+//   op, e := NewPoleRotationGRIBConversion(ctx, &PoleRotationParams{SouthPoleLatitude:-30, SouthPoleLongitude:-15})
+//
+func NewPoleRotationGRIBConversion ( ctx *Context, p *PoleRotationParams ) ( op *Operation, e error ) {
+    defer ctx.capture()(&e)
+    b := &conversionBuilder{ctx:ctx, op:"NewPoleRotationGRIBConversion", units:(*p).ConversionUnits}
+    v := [3]C.double{
+        C.double(b.latitude("SouthPoleLatitude", (*p).SouthPoleLatitude)),
+        C.double(b.longitude("SouthPoleLongitude", (*p).SouthPoleLongitude)),
+        C.double(b.angle("AxisRotation", (*p).AxisRotation, 360.0)),
+    }
+    return b.build(func ( ang *C.char, angf C.double, _ *C.char, _ C.double ) *C.PJ {
+        return C.proj_create_conversion_pole_rotation_grib_convention((*ctx).pj, v[0], v[1], v[2], ang, angf)
+    })
+}
+
+// NewProjectedCRS creates a projected reference system from its base
+// geographic reference system, a conversion and a Cartesian coordinate
+// system, nil for easting and northing in meters :
+//
+//   wgs84, _ := NewReferenceSystem(ctx, "EPSG:4326")
+//   utm, _ := NewUTMConversion(ctx, 31, true)
+//   crs, e := NewProjectedCRS(ctx, "WGS 84 / UTM zone 31N", wgs84, utm, nil)
+//
+func NewProjectedCRS ( ctx *Context, name string, base *ReferenceSystem, conversion *Operation, cs *CoordinateSystem ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    switch base.TypeOf() {
+    case Geographic2DCRS, Geographic3DCRS :
+    default :
+        e = newError(ctx, "NewProjectedCRS", name, ErrNotACRS, "Expected a geographic base CRS")
+        return
+    }
+    if conversion.TypeOf() != Conversion {
+        e = newError(ctx, "NewProjectedCRS", name, ErrNotAnOperation, "Expected a conversion")
+        return
+    }
+    if cs == nil {
+        if cs, e = NewCartesian2DCS(ctx, EastingNorthing, nil) ; e != nil {
+            return
+        }
+        defer cs.DestroyCoordinateSystem()
+    }
+    if cs.Type(ctx) != CartesianCS {
+        e = newError(ctx, "NewProjectedCRS", name, ErrInvalidParameter, "Expected a Cartesian coordinate system")
+        return
+    }
+    defer base.use.enter(base, (*base).ctx, ctx)()
+    defer conversion.use.enter(conversion, (*conversion).ctx, ctx)()
+    defer runtime.KeepAlive(base)
+    defer runtime.KeepAlive(conversion)
+    defer runtime.KeepAlive(cs)
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    pj := C.proj_create_projected_crs((*ctx).pj, cname, (*base).pj, (*conversion).pj, (*cs).pj)
+    if pj == nil {
+        e = contextError(ctx, "NewProjectedCRS", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
@@ -0,0 +1,150 @@
+package proj
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+// Tests :
+
+// TestProjectedCRS checks Lambert-93 is rebuilt from its conversion.
+func TestProjectedCRS ( t *testing.T ) {
+    lcc, e := NewLambertConicConformal2SPConversion(ctx, &FalseOriginParams{
+        Latitude:46.5, Longitude:3, FirstParallel:49, SecondParallel:44,
+        Easting:700000, Northing:6600000,
+    })
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer lcc.DestroyOperation()
+    if lcc.TypeOf() != Conversion {
+        t.Errorf("Expected a conversion, but got %v", lcc.TypeOf())
+    }
+    rgf93, e := NewReferenceSystem(ctx, "EPSG:4171")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer rgf93.DestroyReferenceSystem()
+    l93, e := NewProjectedCRS(ctx, "RGF93 / Lambert-93", rgf93, lcc, nil)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer l93.DestroyReferenceSystem()
+    if l93.TypeOf() != ProjectedCRS {
+        t.Errorf("Expected a projected CRS, but got %v", l93.TypeOf())
+    }
+    ps := l93.ProjString(ctx, Version5)
+    for _, p := range []string{"+proj=lcc", "+lat_1=49", "+lat_2=44", "+x_0=700000", "+y_0=6600000"} {
+        if !strings.Contains(ps, p) {
+            t.Errorf("Expected '%s' in '%s'", p, ps)
+        }
+    }
+    if _, e = NewProjectedCRS(ctx, "bad", l93, lcc, nil) ; !errors.Is(e, ErrNotACRS) {
+        t.Errorf("Expected ErrNotACRS for a projected base CRS, but got %v", e)
+    }
+    geocentric, e := NewReferenceSystem(ctx, "EPSG:4978")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer geocentric.DestroyReferenceSystem()
+    if _, e = NewProjectedCRS(ctx, "bad", geocentric, lcc, nil) ; !errors.Is(e, ErrNotACRS) {
+        t.Errorf("Expected ErrNotACRS for a geocentric base CRS, but got %v", e)
+    }
+}
+
+// TestConversionParameters checks the parameters are validated.
+func TestConversionParameters ( t *testing.T ) {
+    ft, e := GetUnitByID("ft")
+    if e != nil {
+        t.Fatal(e)
+    }
+    grad, e := GetAngularUnitByID("grad")
+    if e != nil {
+        t.Fatal(e)
+    }
+    tm, e := NewTransverseMercatorConversion(ctx, &NaturalOriginParams{
+        ConversionUnits:ConversionUnits{Angular:grad, Linear:ft},
+        Latitude:50, Longitude:3, Scale:0.9996, FalseEasting:1640416.67,
+    })
+    if e != nil {
+        t.Fatal(e)
+    }
+    tm.DestroyOperation()
+    for _, c := range []struct {
+        name    string
+        f       func () ( *Operation, error )
+    }{
+        {"zone", func () ( *Operation, error ) { return NewUTMConversion(ctx, 61, true) }},
+        {"latitude", func () ( *Operation, error ) { return NewTransverseMercatorConversion(ctx, &NaturalOriginParams{Latitude:91}) }},
+        {"latitude in grads", func () ( *Operation, error ) { return NewTransverseMercatorConversion(ctx, &NaturalOriginParams{ConversionUnits:ConversionUnits{Angular:grad}, Latitude:101}) }},
+        {"scale", func () ( *Operation, error ) { return NewTransverseMercatorConversion(ctx, &NaturalOriginParams{Scale:-1}) }},
+        {"parallels", func () ( *Operation, error ) { return NewLambertConicConformal2SPConversion(ctx, &FalseOriginParams{FirstParallel:30, SecondParallel:-30}) }},
+        {"pole", func () ( *Operation, error ) { return NewPolarStereographicVariantAConversion(ctx, &NaturalOriginParams{Latitude:60}) }},
+        {"unit", func () ( *Operation, error ) { return NewCassiniSoldnerConversion(ctx, &CentreParams{ConversionUnits:ConversionUnits{Angular:ft}}) }},
+    } {
+        if op, e := c.f() ; !errors.Is(e, ErrInvalidParameter) {
+            t.Errorf("Expected ErrInvalidParameter for the %s, but got %v", c.name, e)
+            if op != nil {
+                op.DestroyOperation()
+            }
+        }
+    }
+    // the unit is checked before the range of the latitude
+    _, e = NewTransverseMercatorConversion(ctx, &NaturalOriginParams{ConversionUnits:ConversionUnits{Angular:ft}, Latitude:1000})
+    if e == nil || !strings.Contains(e.Error(), "angular unit") {
+        t.Errorf("Expected an error about the angular unit, but got %v", e)
+    }
+}
+
+// TestWorldConversions checks the conversions of world and less common
+// projections give the expected proj-strings.
+func TestWorldConversions ( t *testing.T ) {
+    for _, c := range []struct {
+        proj    string
+        f       func () ( *Operation, error )
+    }{
+        {"+proj=eqearth", func () ( *Operation, error ) { return NewEqualEarthConversion(ctx, &CentralMeridianParams{}) }},
+        {"+proj=robin", func () ( *Operation, error ) { return NewRobinsonConversion(ctx, &CentralMeridianParams{Longitude:10}) }},
+        {"+proj=moll", func () ( *Operation, error ) { return NewMollweideConversion(ctx, &CentralMeridianParams{}) }},
+        {"+proj=eck4", func () ( *Operation, error ) { return NewEckertIVConversion(ctx, &CentralMeridianParams{}) }},
+        {"+proj=wag7", func () ( *Operation, error ) { return NewWagnerVIIConversion(ctx, &CentralMeridianParams{}) }},
+        {"+proj=wag3", func () ( *Operation, error ) { return NewWagnerIIIConversion(ctx, &StandardParallelParams{StandardParallel:30}) }},
+        {"+proj=bonne", func () ( *Operation, error ) { return NewBonneConversion(ctx, &CentreParams{Latitude:45}) }},
+        {"+proj=gnom", func () ( *Operation, error ) { return NewGnomonicConversion(ctx, &CentreParams{Latitude:90}) }},
+        {"+proj=webmerc", func () ( *Operation, error ) { return NewPopularVisualisationPseudoMercatorConversion(ctx, &CentreParams{}) }},
+        {"+proj=cea", func () ( *Operation, error ) { return NewLambertCylindricalEqualAreaConversion(ctx, &StandardParallelParams{StandardParallel:30}) }},
+        {"+proj=eqdc", func () ( *Operation, error ) { return NewEquidistantConicConversion(ctx, &FalseOriginParams{Latitude:40, FirstParallel:30, SecondParallel:50}) }},
+        {"+proj=imw_p", func () ( *Operation, error ) { return NewInternationalMapWorldPolyconicConversion(ctx, &InternationalMapWorldPolyconicParams{FirstParallel:40, SecondParallel:44}) }},
+        {"+proj=geos", func () ( *Operation, error ) { return NewGeostationarySatelliteSweepXConversion(ctx, &GeostationarySatelliteParams{Longitude:-75, Height:35786023}) }},
+        {"+proj=tpeqd", func () ( *Operation, error ) { return NewTwoPointEquidistantConversion(ctx, &TwoPointEquidistantParams{FirstLatitude:40, FirstLongitude:-74, SecondLatitude:51.5}) }},
+        {"+proj=nsper", func () ( *Operation, error ) { return NewVerticalPerspectiveConversion(ctx, &VerticalPerspectiveParams{Latitude:45, ViewPointHeight:35786023}) }},
+        {"+proj=ob_tran", func () ( *Operation, error ) { return NewPoleRotationGRIBConversion(ctx, &PoleRotationParams{SouthPoleLatitude:-30, SouthPoleLongitude:-15}) }},
+    } {
+        op, e := c.f()
+        if e != nil {
+            t.Errorf("Expected a %s conversion, but got %v", c.proj, e)
+            continue
+        }
+        if ps := op.ProjString(ctx, Version5) ; !strings.Contains(ps, c.proj) {
+            t.Errorf("Expected '%s' in '%s'", c.proj, ps)
+        }
+        op.DestroyOperation()
+    }
+    for _, c := range []struct {
+        name    string
+        f       func () ( *Operation, error )
+    }{
+        {"central meridian", func () ( *Operation, error ) { return NewSinusoidalConversion(ctx, &CentralMeridianParams{Longitude:400}) }},
+        {"satellite height", func () ( *Operation, error ) { return NewGeostationarySatelliteSweepYConversion(ctx, &GeostationarySatelliteParams{Height:-1}) }},
+        {"points", func () ( *Operation, error ) { return NewTwoPointEquidistantConversion(ctx, &TwoPointEquidistantParams{FirstLatitude:10, SecondLatitude:10}) }},
+        {"ellipsoid scale", func () ( *Operation, error ) { return NewLambertConicConformal2SPMichiganConversion(ctx, &FalseOriginParams{FirstParallel:42, SecondParallel:44}, -1) }},
+    } {
+        if op, e := c.f() ; !errors.Is(e, ErrInvalidParameter) {
+            t.Errorf("Expected ErrInvalidParameter for the %s, but got %v", c.name, e)
+            if op != nil {
+                op.DestroyOperation()
+            }
+        }
+    }
+}
@@ -185,6 +185,20 @@ type Locatable interface {
     SetLocation( *Coordinate )
 }
 
+// LocatableBatch allows working on the coordinates of a collection of items
+// at once : they are transformed with a single call to PROJ.
+//
+type LocatableBatch interface {
+    // Len returns the number of items in the collection
+    Len             ()                  int
+    // Coordinates copies the items' coordinates into `buf` (holding Len()
+    // coordinates)
+    Coordinates     ( buf []Coordinate )
+    // SetCoordinates assigns the items' coordinates from `buf` (holding
+    // Len() coordinates)
+    SetCoordinates  ( buf []Coordinate )
+}
+
 // Location returns coordinates. Here itself !
 //
 func (c *Coordinate) Location() (xyzt *Coordinate) {
@@ -0,0 +1,312 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "fmt"
+    "runtime"
+    "unsafe"
+)
+
+// Axis describes an axis of a coordinate system.
+//
+type Axis struct {
+    // Name is the name of the axis, e.g. "Easting"
+    Name            string
+    // Abbreviation is the abbreviation of the axis, e.g. "E"
+    Abbreviation    string
+    // Direction is the direction of the axis, e.g. "east"
+    Direction       string
+    // Unit is the unit of the axis
+    Unit            *Unit
+}
+
+// CoordinateSystem contains an internal object that holds the axes of a
+// reference system.
+//
+type CoordinateSystem struct {
+    pj  *C.PJ
+    ctx *Context    // owning context
+    use usage       // concurrent use tracker
+}
+
+// NewCoordinateSystem creates a coordinate system from its type and axes :
+//
+//   m, _ := GetUnitByID("m")
+//   cs, e := NewCoordinateSystem(ctx, CartesianCS, []Axis{
+//       {Name:"Westing", Abbreviation:"W", Direction:"west", Unit:m},
+//       {Name:"Northing", Abbreviation:"N", Direction:"north", Unit:m},
+//   })
+//
+func NewCoordinateSystem ( ctx *Context, typ CoordinateSystemType, axes []Axis ) ( cs *CoordinateSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    if len(axes) == 0 {
+        e = newError(ctx, "NewCoordinateSystem", "", ErrInvalidParameter, "No axis")
+        return
+    }
+    caxes := (*C.PJ_AXIS_DESCRIPTION)(C.calloc(C.size_t(len(axes)), C.size_t(unsafe.Sizeof(C.PJ_AXIS_DESCRIPTION{}))))
+    defer C.free(unsafe.Pointer(caxes))
+    cas := unsafe.Slice(caxes, len(axes))
+    for i, a := range axes {
+        if a.Unit == nil {
+            e = newError(ctx, "NewCoordinateSystem", a.Name, ErrInvalidParameter, "Missing unit")
+            return
+        }
+        cat, ok := unitTypes[a.Unit.Category()]
+        if !ok {
+            e = newError(ctx, "NewCoordinateSystem", a.Name, ErrInvalidParameter, fmt.Sprintf("Unexpected %s unit '%s'", a.Unit.Category(), a.Unit.Name()))
+            return
+        }
+        cas[i].name = C.CString(a.Name)
+        defer C.free(unsafe.Pointer(cas[i].name))
+        cas[i].abbreviation = C.CString(a.Abbreviation)
+        defer C.free(unsafe.Pointer(cas[i].abbreviation))
+        cas[i].direction = C.CString(a.Direction)
+        defer C.free(unsafe.Pointer(cas[i].direction))
+        cas[i].unit_name = C.CString(a.Unit.Name())
+        defer C.free(unsafe.Pointer(cas[i].unit_name))
+        cas[i].unit_conv_factor = C.double(a.Unit.ToSI())
+        cas[i].unit_type = cat
+    }
+    pj := C.proj_create_cs((*ctx).pj, C.PJ_COORDINATE_SYSTEM_TYPE(typ), C.int(len(axes)), caxes)
+    if pj == nil {
+        e = contextError(ctx, "NewCoordinateSystem", "", ErrInvalidDefinition)
+        return
+    }
+    cs = newCoordinateSystem(ctx, pj)
+    return
+}
+
+// NewCartesian2DCS creates a usual Cartesian 2D coordinate system for
+// projected CRS. `unit` is the linear unit of both axes, nil for meter.
+//
+func NewCartesian2DCS ( ctx *Context, typ Cartesian2DType, unit *Unit ) ( cs *CoordinateSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    name, factor, e := unitParameter(ctx, "NewCartesian2DCS", "unit", unit, LinearUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(name))
+    pj := C.proj_create_cartesian_2D_cs((*ctx).pj, C.PJ_CARTESIAN_CS_2D_TYPE(typ), name, factor)
+    if pj == nil {
+        e = contextError(ctx, "NewCartesian2DCS", "", ErrInvalidDefinition)
+        return
+    }
+    cs = newCoordinateSystem(ctx, pj)
+    return
+}
+
+// NewEllipsoidal2DCS creates an ellipsoidal 2D coordinate system for
+// geographic CRS. `unit` is the angular unit of both axes, nil for degree.
+//
+func NewEllipsoidal2DCS ( ctx *Context, typ Ellipsoidal2DType, unit *Unit ) ( cs *CoordinateSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    name, factor, e := unitParameter(ctx, "NewEllipsoidal2DCS", "unit", unit, AngularUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(name))
+    pj := C.proj_create_ellipsoidal_2D_cs((*ctx).pj, C.PJ_ELLIPSOIDAL_CS_2D_TYPE(typ), name, factor)
+    if pj == nil {
+        e = contextError(ctx, "NewEllipsoidal2DCS", "", ErrInvalidDefinition)
+        return
+    }
+    cs = newCoordinateSystem(ctx, pj)
+    return
+}
+
+// NewEllipsoidal3DCS creates an ellipsoidal 3D coordinate system for
+// geographic CRS. `angular` is the unit of the horizontal axes, nil for
+// degree, `linear` the one of the height, nil for meter.
+//
+func NewEllipsoidal3DCS ( ctx *Context, typ Ellipsoidal3DType, angular *Unit, linear *Unit ) ( cs *CoordinateSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    aname, afactor, e := unitParameter(ctx, "NewEllipsoidal3DCS", "angular", angular, AngularUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(aname))
+    lname, lfactor, e := unitParameter(ctx, "NewEllipsoidal3DCS", "linear", linear, LinearUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(lname))
+    pj := C.proj_create_ellipsoidal_3D_cs((*ctx).pj, C.PJ_ELLIPSOIDAL_CS_3D_TYPE(typ), aname, afactor, lname, lfactor)
+    if pj == nil {
+        e = contextError(ctx, "NewEllipsoidal3DCS", "", ErrInvalidDefinition)
+        return
+    }
+    cs = newCoordinateSystem(ctx, pj)
+    return
+}
+
+// newCoordinateSystem wraps the PROJ pointer created in the given context.
+//
+func newCoordinateSystem ( ctx *Context, pj *C.PJ ) *CoordinateSystem {
+    cs := &CoordinateSystem{pj:pj, ctx:ctx}
+    allocated()
+    runtime.SetFinalizer(cs, func ( cs *CoordinateSystem ) {
+        leaked(cs)
+        destroyPJ(nil, (*cs).pj)
+    })
+    return cs
+}
+
+// DestroyCoordinateSystem deallocates the internal coordinate system object.
+//
+func (cs *CoordinateSystem) DestroyCoordinateSystem () {
+    if (*cs).pj != nil {
+        destroyPJ((*cs).ctx, (*cs).pj)
+        (*cs).pj = nil
+        runtime.SetFinalizer(cs, nil)
+    }
+}
+
+// Close deallocates the internal coordinate system object. It implements
+// io.Closer.
+//
+func (cs *CoordinateSystem) Close () error {
+    cs.DestroyCoordinateSystem()
+    return nil
+}
+
+// Handle returns the PROJ internal object to be passed to the PROJ library
+//
+func (cs *CoordinateSystem) Handle () (interface{}) {
+    return (*cs).pj
+}
+
+// HandleIsNil returns true when the PROJ internal object is NULL.
+//
+func (cs *CoordinateSystem) HandleIsNil () bool {
+    return (*cs).pj == (*C.PJ)(nil)
+}
+
+// Context returns the context owning the coordinate system.
+//
+func (cs *CoordinateSystem) Context () *Context {
+    return (*cs).ctx
+}
+
+// Type returns the type of the coordinate system.
+//
+func (cs *CoordinateSystem) Type ( ctx *Context ) CoordinateSystemType {
+    defer cs.use.enter(cs, (*cs).ctx, ctx)()
+    defer runtime.KeepAlive(cs)
+    return CoordinateSystemType(C.proj_cs_get_type((*ctx).pj, (*cs).pj))
+}
+
+// Axes returns the axes of the coordinate system.
+//
+func (cs *CoordinateSystem) Axes ( ctx *Context ) ( axes []Axis, e error ) {
+    defer cs.use.enter(cs, (*cs).ctx, ctx)()
+    defer runtime.KeepAlive(cs)
+    n := int(C.proj_cs_get_axis_count((*ctx).pj, (*cs).pj))
+    if n < 0 {
+        e = contextError(ctx, "Axes", "", nil)
+        return
+    }
+    typ := CoordinateSystemType(C.proj_cs_get_type((*ctx).pj, (*cs).pj))
+    axes = make([]Axis, n)
+    for i := range axes {
+        var name, abbrev, dir, uname, uauth, ucode *C.char
+        var factor C.double
+        if C.proj_cs_get_axis_info((*ctx).pj, (*cs).pj, C.int(i), &name, &abbrev, &dir, &factor, &uname, &uauth, &ucode) == 0 {
+            e = contextError(ctx, "Axes", "", nil)
+            return nil, e
+        }
+        axes[i] = Axis{
+            Name:C.GoString(name),
+            Abbreviation:C.GoString(abbrev),
+            Direction:C.GoString(dir),
+            Unit:axisUnit(ctx, typ, C.GoString(dir), C.GoString(uname), float64(factor), C.GoString(uauth), C.GoString(ucode)),
+        }
+    }
+    return
+}
+
+// CoordinateSystem returns the coordinate system of the reference system.
+//
+func (crs *ReferenceSystem) CoordinateSystem ( ctx *Context ) ( *CoordinateSystem, error ) {
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    pj := C.proj_crs_get_coordinate_system((*ctx).pj, (*crs).pj)
+    if pj == nil {
+        return nil, contextError(ctx, "CoordinateSystem", crs.String(), ErrNotACRS)
+    }
+    return newCoordinateSystem(ctx, pj), nil
+}
+
+// unitTypes maps the categories of units to the PROJ unit types.
+//
+var unitTypes = map[UnitCategory]C.PJ_UNIT_TYPE{
+    AngularUnit     : C.PJ_UT_ANGULAR,
+    LinearUnit      : C.PJ_UT_LINEAR,
+    ScaleUnit       : C.PJ_UT_SCALE,
+    TimeUnit        : C.PJ_UT_TIME,
+    ParametricUnit  : C.PJ_UT_PARAMETRIC,
+}
+
+// unitParameter checks the category of the unit and returns its name, to
+// be freed by the caller, and its conversion factor. A nil unit yields a
+// NULL name, that is the PROJ default unit (meter, degree).
+//
+func unitParameter ( ctx *Context, op string, param string, u *Unit, cat UnitCategory ) ( *C.char, C.double, error ) {
+    if u == nil {
+        return nil, 0.0, nil
+    }
+    if u.Category() != cat {
+        return nil, 0.0, newError(ctx, op, param, ErrInvalidParameter, fmt.Sprintf("Expected a %s unit, but got %s unit '%s'", cat, u.Category(), u.Name()))
+    }
+    return C.CString(u.Name()), C.double(u.ToSI()), nil
+}
+
+// axisUnit returns the unit of an axis. Its category comes from the database
+// when the unit has an identifier, from the type of the coordinate system
+// and the direction of the axis otherwise.
+//
+func axisUnit ( ctx *Context, typ CoordinateSystemType, dir string, name string, factor float64, auth string, code string ) *Unit {
+    u := &Unit{id:name, name:name, factor:factor, category:axisUnitCategory(typ, dir)}
+    if auth != "" && code != "" {
+        u.id = auth + ":" + code
+        if db, e := ctx.UnitOfMeasure(auth, code) ; e == nil {
+            if _, ok := unitTypes[db.Category()] ; ok {
+                u.category = db.Category()
+            }
+        }
+    }
+    u.toSI = fmt.Sprint(factor)
+    return u
+}
+
+// axisUnitCategory returns the category of the unit of an axis given the
+// type of its coordinate system and its direction.
+//
+func axisUnitCategory ( typ CoordinateSystemType, dir string ) UnitCategory {
+    switch typ {
+    case EllisoidalCS, SphericalCS :
+        if dir == "up" || dir == "down" {
+            return LinearUnit
+        }
+        return AngularUnit
+    case CartesianCS, VerticalCS :
+        return LinearUnit
+    case OrdinalCS :
+        return ScaleUnit
+    case ParametricCS :
+        return ParametricUnit
+    case DateTimeTemporalCS, TemporalCountCS, TemporalMeasureCs :
+        return TimeUnit
+    default :
+        return UnknownUnitCategory
+    }
+}
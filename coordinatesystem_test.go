@@ -0,0 +1,105 @@
+package proj
+
+import (
+    "testing"
+)
+
+// Tests :
+
+// TestCoordinateSystem checks coordinate systems and their axes.
+func TestCoordinateSystem ( t *testing.T ) {
+    ft, e := GetUnitByID("us-ft")
+    if e != nil {
+        t.Fatal(e)
+    }
+    cs, e := NewCartesian2DCS(ctx, NorthingEasting, ft)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer cs.DestroyCoordinateSystem()
+    if cs.Type(ctx) != CartesianCS {
+        t.Errorf("Expected a Cartesian coordinate system, but got %v", cs.Type(ctx))
+    }
+    axes, e := cs.Axes(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(axes) != 2 || axes[0].Direction != "north" || axes[1].Direction != "east" {
+        t.Fatalf("Expected north and east axes, but got %v", axes)
+    }
+    if axes[0].Unit.ToSI() != ft.ToSI() {
+        t.Errorf("Expected %v, but got %v", ft.ToSI(), axes[0].Unit.ToSI())
+    }
+    deg, _ := GetAngularUnitByID("deg")
+    if _, e = NewCartesian2DCS(ctx, EastingNorthing, deg) ; e == nil {
+        t.Errorf("Expected an error for an angular unit")
+    }
+    m, _ := GetUnitByID("m")
+    ws, e := NewCoordinateSystem(ctx, CartesianCS, []Axis{
+        {Name:"Westing", Abbreviation:"W", Direction:"west", Unit:m},
+        {Name:"Southing", Abbreviation:"S", Direction:"south", Unit:m},
+    })
+    if e != nil {
+        t.Fatal(e)
+    }
+    ws.DestroyCoordinateSystem()
+    wgs84, e := NewReferenceSystem(ctx, "EPSG:4326")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer wgs84.DestroyReferenceSystem()
+    ell, e := wgs84.CoordinateSystem(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer ell.DestroyCoordinateSystem()
+    if ell.Type(ctx) != EllisoidalCS {
+        t.Errorf("Expected an ellipsoidal coordinate system, but got %v", ell.Type(ctx))
+    }
+}
+
+// TestCoordinateSystemFromAxes checks a coordinate system is rebuilt from
+// its own axes.
+func TestCoordinateSystemFromAxes ( t *testing.T ) {
+    for _, id := range []string{"EPSG:4979", "EPSG:2154", "EPSG:4978"} {
+        crs, e := NewReferenceSystem(ctx, id)
+        if e != nil {
+            t.Fatal(e)
+        }
+        defer crs.DestroyReferenceSystem()
+        cs, e := crs.CoordinateSystem(ctx)
+        if e != nil {
+            t.Fatal(e)
+        }
+        defer cs.DestroyCoordinateSystem()
+        axes, e := cs.Axes(ctx)
+        if e != nil {
+            t.Fatal(e)
+        }
+        rebuilt, e := NewCoordinateSystem(ctx, cs.Type(ctx), axes)
+        if e != nil {
+            t.Errorf("Expected the coordinate system of %s to be rebuilt, but got %v", id, e)
+            continue
+        }
+        defer rebuilt.DestroyCoordinateSystem()
+        raxes, _ := rebuilt.Axes(ctx)
+        if len(raxes) != len(axes) {
+            t.Fatalf("Expected %d axes, but got %d", len(axes), len(raxes))
+        }
+        for i := range axes {
+            if raxes[i].Direction != axes[i].Direction || raxes[i].Unit.Category() != axes[i].Unit.Category() {
+                t.Errorf("Expected axis %v, but got %v", axes[i], raxes[i])
+            }
+        }
+    }
+    crs, _ := NewReferenceSystem(ctx, "EPSG:4979")
+    defer crs.DestroyReferenceSystem()
+    cs, _ := crs.CoordinateSystem(ctx)
+    defer cs.DestroyCoordinateSystem()
+    axes, _ := cs.Axes(ctx)
+    for i, cat := range []UnitCategory{AngularUnit, AngularUnit, LinearUnit} {
+        if axes[i].Unit.Category() != cat {
+            t.Errorf("Expected a %s unit for axis %s, but got %s", cat, axes[i].Name, axes[i].Unit.Category())
+        }
+    }
+}
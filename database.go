@@ -0,0 +1,245 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "runtime"
+    "unsafe"
+)
+
+// Codes returns the codes of the objects of type `typ` known by the
+// authority in the database, e.g. all projected CRS codes of "EPSG" :
+//
+//   codes, e := ctx.Codes("EPSG", ProjectedCRS, false)
+//
+// CRS and GeographicCRS encompass their subtypes. Deprecated objects are
+// only listed when `allowDeprecated` is true.
+//
+func (ctx *Context) Codes ( authority string, typ ISOType, allowDeprecated bool ) ( []string, error ) {
+    defer runtime.KeepAlive(ctx)
+    auth := C.CString(authority)
+    defer C.free(unsafe.Pointer(auth))
+    deprecated := C.int(0)
+    if allowDeprecated {
+        deprecated = C.int(1)
+    }
+    codes := C.proj_get_codes_from_database((*ctx).pj, auth, C.PJ_TYPE(typ), deprecated)
+    if codes == nil {
+        if !ctx.IsAnAuthority(authority) {
+            return nil, newError(ctx, "Codes", authority, ErrUnknownAuthorityCode, "Unknown authority")
+        }
+        return nil, contextError(ctx, "Codes", authority, nil)
+    }
+    defer C.proj_string_list_destroy(codes)
+    return goStrings(codes), nil
+}
+
+// goStrings returns the content of a PROJ list of strings.
+//
+func goStrings ( l C.PROJ_STRING_LIST ) []string {
+    var s []string
+    for i := 0 ; ; i++ {
+        cs := C.getAuthorityFromPROJ(l, C.int(i))
+        if cs == nil {
+            break
+        }
+        s = append(s, C.GoString(cs))
+    }
+    return s
+}
+
+// cTypes returns a C array of the types to be freed by the caller, nil when
+// there is no type.
+//
+func cTypes ( types []ISOType ) *C.PJ_TYPE {
+    l := len(types)
+    if l == 0 {
+        return nil
+    }
+    ctypes := (*C.PJ_TYPE)(C.malloc(C.size_t(l) * C.size_t(unsafe.Sizeof(C.PJ_TYPE(0)))))
+    for i, t := range types {
+        unsafe.Slice(ctypes, l)[i] = C.PJ_TYPE(t)
+    }
+    return ctypes
+}
+
+// BBox is a bounding box in degrees. In the case of an area crossing the
+// antimeridian, `West` is greater than `East`.
+//
+type BBox struct {
+    West    float64 // western-most longitude
+    South   float64 // southern-most latitude
+    East    float64 // eastern-most longitude
+    North   float64 // northern-most latitude
+}
+
+// CRSSearchParams holds the filters of `SearchCRS`. Its zero value selects
+// every non deprecated CRS of every authority.
+//
+type CRSSearchParams struct {
+    // Authority restricts the search to one authority, e.g. "EPSG"
+    Authority       string
+    // Types restricts the search to these types, e.g. ProjectedCRS
+    Types           []ISOType
+    // BBox restricts the search to the CRS whose area of use intersects it
+    BBox            *BBox
+    // Contains restricts the search to the CRS whose area of use entirely
+    // contains BBox
+    Contains        bool
+    // AllowDeprecated includes deprecated CRS
+    AllowDeprecated bool
+}
+
+// CRSInfo describes a CRS of the database without instantiating it.
+//
+type CRSInfo struct {
+    Authority           string
+    Code                string
+    Name                string
+    Type                ISOType
+    Deprecated          bool
+    // AreaName is the name of the area of use
+    AreaName            string
+    // BBox is the area of use, nil if unknown
+    BBox                *BBox
+    // ProjectionMethod is the name of the projection method of a projected
+    // CRS, might be empty
+    ProjectionMethod    string
+}
+
+// ID returns the identifier of the CRS, e.g. "EPSG:2154".
+//
+func (i *CRSInfo) ID () string {
+    return (*i).Authority + ":" + (*i).Code
+}
+
+// ReferenceSystem instantiates the described CRS.
+//
+func (i *CRSInfo) ReferenceSystem ( ctx *Context ) ( *ReferenceSystem, error ) {
+    return NewReferenceSystem(ctx, i.ID())
+}
+
+// SearchCRS returns the CRS of the database matching the parameters, e.g.
+// the projected CRS covering Paris :
+//
+//   crss, e := ctx.SearchCRS(&CRSSearchParams{
+//       Authority:"EPSG",
+//       Types:[]ISOType{ProjectedCRS},
+//       BBox:&BBox{West:2.2, South:48.8, East:2.5, North:48.9},
+//       Contains:true,
+//   })
+//
+// A nil `params` selects every non deprecated CRS.
+//
+func (ctx *Context) SearchCRS ( params *CRSSearchParams ) ( []CRSInfo, error ) {
+    defer runtime.KeepAlive(ctx)
+    if params == nil {
+        params = &CRSSearchParams{}
+    }
+    var auth *C.char
+    if params.Authority != "" {
+        auth = C.CString(params.Authority)
+        defer C.free(unsafe.Pointer(auth))
+    }
+    cparams := C.proj_get_crs_list_parameters_create()
+    defer C.proj_get_crs_list_parameters_destroy(cparams)
+    if l := len(params.Types) ; l > 0 {
+        types := cTypes(params.Types)
+        defer C.free(unsafe.Pointer(types))
+        (*cparams).types = types
+        (*cparams).typesCount = C.size_t(l)
+    }
+    if params.BBox != nil {
+        (*cparams).bbox_valid = C.int(1)
+        (*cparams).west_lon_degree = C.double(params.BBox.West)
+        (*cparams).south_lat_degree = C.double(params.BBox.South)
+        (*cparams).east_lon_degree = C.double(params.BBox.East)
+        (*cparams).north_lat_degree = C.double(params.BBox.North)
+        if params.Contains {
+            (*cparams).crs_area_of_use_contains_bbox = C.int(1)
+        }
+    }
+    if params.AllowDeprecated {
+        (*cparams).allow_deprecated = C.int(1)
+    }
+    var count C.int
+    list := C.proj_get_crs_info_list_from_database((*ctx).pj, auth, cparams, &count)
+    if list == nil {
+        return nil, contextError(ctx, "SearchCRS", params.Authority, nil)
+    }
+    defer C.proj_crs_info_list_destroy(list)
+    infos := make([]CRSInfo, int(count))
+    for i := range infos {
+        ci := C.getCRSInfoFromPROJ(list, C.int(i))
+        infos[i] = CRSInfo{
+            Authority:C.GoString((*ci).auth_name),
+            Code:C.GoString((*ci).code),
+            Name:C.GoString((*ci).name),
+            Type:ISOType((*ci)._type),
+            Deprecated:(*ci).deprecated != C.int(0),
+            AreaName:C.GoString((*ci).area_name),
+            ProjectionMethod:C.GoString((*ci).projection_method_name),
+        }
+        if (*ci).bbox_valid != C.int(0) {
+            infos[i].BBox = &BBox{
+                West:float64((*ci).west_lon_degree),
+                South:float64((*ci).south_lat_degree),
+                East:float64((*ci).east_lon_degree),
+                North:float64((*ci).north_lat_degree),
+            }
+        }
+    }
+    return infos, nil
+}
+
+// FindByName returns the objects of the database whose name matches
+// `name`, e.g. "WGS 84 / UTM zone 32N", best matches first. `types`
+// restricts the search to these types (nil for any type), `approximate`
+// allows partial matches ("Lambert 93"), `limit` caps the number of results
+// (0 for no limit) and `authority` restricts the search to one authority.
+// Objects are *ReferenceSystem, *Operation, *Ellipsoid, *PrimeMeridian or
+// *Datum. They must be closed by the caller.
+//
+//   objs, e := ctx.FindByName("Lambert 93", []ISOType{ProjectedCRS}, true, 5, "EPSG")
+//
+func (ctx *Context) FindByName ( name string, types []ISOType, approximate bool, limit int, authority ...string ) ( []ISOObject, error ) {
+    defer runtime.KeepAlive(ctx)
+    var auth *C.char
+    if len(authority) > 0 && authority[0] != "" {
+        auth = C.CString(authority[0])
+        defer C.free(unsafe.Pointer(auth))
+    }
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    ctypes := cTypes(types)
+    defer C.free(unsafe.Pointer(ctypes))
+    approx := C.int(0)
+    if approximate {
+        approx = C.int(1)
+    }
+    if limit < 0 {
+        limit = 0
+    }
+    list := C.proj_create_from_name((*ctx).pj, auth, cname, ctypes, C.size_t(len(types)), approx, C.size_t(limit), nil)
+    if list == nil {
+        return nil, contextError(ctx, "FindByName", name, nil)
+    }
+    defer C.proj_list_destroy(list)
+    n := int(C.proj_list_get_count(list))
+    objs := make([]ISOObject, 0, n)
+    for i := 0 ; i < n ; i++ {
+        pj := C.proj_list_get((*ctx).pj, list, C.int(i))
+        if pj == nil {
+            continue
+        }
+        if o := newISOObject(ctx, pj) ; o != nil {
+            objs = append(objs, o)
+        }
+    }
+    return objs, nil
+}
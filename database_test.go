@@ -0,0 +1,135 @@
+package proj
+
+import (
+    "testing"
+)
+
+// Tests :
+
+// TestCodes checks codes are listed by type.
+func TestCodes ( t *testing.T ) {
+    contains := func ( codes []string, code string ) bool {
+        for _, c := range codes {
+            if c == code {
+                return true
+            }
+        }
+        return false
+    }
+    tests := []struct{
+        typ     ISOType
+        code    string
+    }{
+        {ProjectedCRS, "2154"},
+        {GeographicCRS, "4326"},
+        {Geographic2DCRS, "4326"},
+        {CRS, "4978"},
+        {EllipsoidType, "7019"},
+        {PrimeMeridianType, "8901"},
+        {GeodeticReferenceFrame, "6326"},
+        {Conversion, "16031"},
+    }
+    for _, test := range tests {
+        codes, e := ctx.Codes("EPSG", test.typ, false)
+        if e != nil {
+            t.Errorf("%d: %v", test.typ, e)
+            continue
+        }
+        if !contains(codes, test.code) {
+            t.Errorf("%d: expected EPSG:%s in %d codes", test.typ, test.code, len(codes))
+        }
+    }
+    projected, _ := ctx.Codes("EPSG", ProjectedCRS, false)
+    if contains(projected, "4326") {
+        t.Errorf("Unexpected EPSG:4326 among projected CRS")
+    }
+    all, _ := ctx.Codes("EPSG", ProjectedCRS, true)
+    if len(all) <= len(projected) {
+        t.Errorf("Expected deprecated codes to be listed, got %d vs %d", len(all), len(projected))
+    }
+}
+
+// TestSearchCRS checks the CRS catalogue filters.
+func TestSearchCRS ( t *testing.T ) {
+    paris := &BBox{West:2.2, South:48.8, East:2.5, North:48.9}
+    crss, e := ctx.SearchCRS(&CRSSearchParams{
+        Authority:"EPSG",
+        Types:[]ISOType{ProjectedCRS},
+        BBox:paris,
+        Contains:true,
+    })
+    if e != nil {
+        t.Fatal(e)
+    }
+    found := false
+    for _, crs := range crss {
+        if crs.Type != ProjectedCRS || crs.Authority != "EPSG" || crs.Deprecated {
+            t.Errorf("Unexpected %s (type %d, deprecated %t)", crs.ID(), crs.Type, crs.Deprecated)
+        }
+        if crs.BBox == nil || crs.BBox.West > paris.West || crs.BBox.North < paris.North {
+            t.Errorf("Expected %s area of use to contain Paris, got %v", crs.ID(), crs.BBox)
+        }
+        if crs.Code == "2154" {
+            found = true
+            if crs.Name != "RGF93 / Lambert-93" || crs.ProjectionMethod == "" || crs.AreaName == "" {
+                t.Errorf("Unexpected EPSG:2154 description %+v", crs)
+            }
+        }
+    }
+    if !found {
+        t.Fatalf("Expected EPSG:2154 among %d CRS", len(crss))
+    }
+    intersecting, e := ctx.SearchCRS(&CRSSearchParams{Authority:"EPSG", Types:[]ISOType{ProjectedCRS}, BBox:paris})
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(intersecting) < len(crss) {
+        t.Errorf("Expected more CRS intersecting than containing Paris, got %d vs %d", len(intersecting), len(crss))
+    }
+    crs, e := crss[0].ReferenceSystem(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    crs.DestroyReferenceSystem()
+}
+
+// TestFindByName checks objects are found by their name.
+func TestFindByName ( t *testing.T ) {
+    objs, e := ctx.FindByName("WGS 84 / UTM zone 32N", []ISOType{ProjectedCRS}, false, 0, "EPSG")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(objs) != 1 {
+        t.Fatalf("Expected one exact match, but got %d", len(objs))
+    }
+    crs, ok := objs[0].(*ReferenceSystem)
+    if !ok {
+        t.Errorf("Expected a *ReferenceSystem, but got %T", objs[0])
+    } else if d := crs.Info().Description() ; d != "WGS 84 / UTM zone 32N" {
+        t.Errorf("Expected 'WGS 84 / UTM zone 32N', but got '%s'", d)
+    }
+    objs[0].Close()
+    objs, e = ctx.FindByName("Lambert 93", nil, true, 3)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(objs) == 0 || len(objs) > 3 {
+        t.Errorf("Expected 1 to 3 approximate matches, but got %d", len(objs))
+    }
+    for _, o := range objs {
+        o.Close()
+    }
+    objs, e = ctx.FindByName("WGS 84", []ISOType{EllipsoidType}, false, 0)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(objs) == 0 {
+        t.Fatalf("Expected the WGS 84 ellipsoid")
+    }
+    if _, ok = objs[0].(*Ellipsoid) ; !ok {
+        t.Errorf("Expected an *Ellipsoid, but got %T", objs[0])
+    }
+    for _, o := range objs {
+        o.Close()
+    }
+}
@@ -0,0 +1,177 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "runtime"
+)
+
+// Datum contains an internal object that holds everything related to a
+// given datum (reference frame).
+type Datum struct {
+    pj  *C.PJ
+    ctx *Context    // owning context
+    use usage       // concurrent use tracker
+}
+
+// NewDatum creates a datum from a WKT string or a URI.
+//
+//   d, e := NewDatum(ctx, "EPSG:6171")
+//
+func NewDatum ( ctx *Context, def string ) ( d *Datum, e error ) {
+    defer ctx.capture()(&e)
+    var pj *C.PJ
+    pj, e = NewPJ(ctx, def, "Datum", C.PJ_CATEGORY_DATUM)
+    if e == nil {
+        if t := ISOType(C.proj_get_type(pj)) ; t < GeodeticReferenceFrame || t > DatumEnsemble {
+            C.proj_destroy(pj)
+            pj = nil
+            e = newError(ctx, "NewDatum", def, ErrInvalidDefinition, "does not yield a Datum")
+            return
+        }
+        d = newDatum(ctx, pj)
+    }
+    return
+}
+
+// newDatum wraps the PROJ pointer created in the given context.
+//
+func newDatum ( ctx *Context, pj *C.PJ ) *Datum {
+    d := &Datum{pj:pj, ctx:ctx}
+    allocated()
+    runtime.SetFinalizer(d, func ( d *Datum ) {
+        leaked(d)
+        destroyPJ(nil, (*d).pj)
+    })
+    return d
+}
+
+// DestroyDatum deallocates the internal datum object.
+//
+func (d *Datum) DestroyDatum () {
+    if (*d).pj != nil {
+        destroyPJ((*d).ctx, (*d).pj)
+        (*d).pj = nil
+        runtime.SetFinalizer(d, nil)
+    }
+}
+
+// Close deallocates the internal Datum object. It implements io.Closer.
+//
+func (d *Datum) Close () error {
+    d.DestroyDatum()
+    return nil
+}
+
+// Handle returns the PROJ internal object to be passed to the PROJ library
+//
+func (d *Datum) Handle () (interface{}) {
+    return (*d).pj
+}
+
+// HandleIsNil returns true when the PROJ internal object is NULL.
+//
+func (d *Datum) HandleIsNil () bool {
+    return (*d).pj == (*C.PJ)(nil)
+}
+
+// Context returns the context owning the datum.
+//
+func (d *Datum) Context () *Context {
+    return (*d).ctx
+}
+
+// guard returns the concurrent use tracker of the datum.
+//
+func (d *Datum) guard () *usage {
+    return &((*d).use)
+}
+
+// TypeOf returns the ISOType of a datum (GeodeticReferenceFrame,
+// VerticalReferenceFrame, ...). UnKnownType on error.
+//
+func (d *Datum) TypeOf ( ) ISOType {
+    return hasType(d)
+}
+
+// Name returns the name of the datum.
+//
+func (d *Datum) Name ( ) string {
+    defer runtime.KeepAlive(d)
+    return C.GoString(C.proj_get_name((*d).pj))
+}
+
+// Ellipsoid returns the ellipsoid of a geodetic datum.
+//
+func (d *Datum) Ellipsoid ( ctx *Context ) ( *Ellipsoid, error ) {
+    defer d.use.enter(d, (*d).ctx, ctx)()
+    defer runtime.KeepAlive(d)
+    pj := C.proj_get_ellipsoid((*ctx).pj, (*d).pj)
+    if pj == nil {
+        return nil, contextError(ctx, "Ellipsoid", d.Name(), ErrNotAnEllipsoid)
+    }
+    return newEllipsoid(ctx, pj), nil
+}
+
+// PrimeMeridian returns the prime meridian of a geodetic datum.
+//
+func (d *Datum) PrimeMeridian ( ctx *Context ) ( *PrimeMeridian, error ) {
+    defer d.use.enter(d, (*d).ctx, ctx)()
+    defer runtime.KeepAlive(d)
+    pj := C.proj_get_prime_meridian((*ctx).pj, (*d).pj)
+    if pj == nil {
+        return nil, contextError(ctx, "PrimeMeridian", d.Name(), ErrNotAPrimeMeridian)
+    }
+    return newPrimeMeridian(ctx, pj), nil
+}
+
+// Info returns information about a specific datum object.
+//
+func (d *Datum) Info ( ) ( *ISOInfo ) {
+    defer runtime.KeepAlive(d)
+    return &ISOInfo{pj:C.proj_pj_info((*d).pj)}
+}
+
+// String returns a string representation of the datum.
+//
+func (d *Datum) String ( ) string {
+    return d.Name()
+}
+
+// ProjString returns a proj-string representation of the datum.
+// Empty string is returned on error (no conversion for datums).
+//
+func (d *Datum) ProjString ( ctx *Context, styp StringType, opts ...string ) string {
+    return toProj(ctx, d, styp, opts)
+}
+
+// Wkt return returns a WKT representation of the datum.
+// Empty string is returned on error.
+//
+func (d *Datum) Wkt ( ctx *Context, styp WKTType, opts ...string ) string {
+    return toWkt(ctx, d, styp, opts)
+}
+
+// ProjJSON returns a PROJJSON representation of the datum.
+// Empty string is returned on error.
+//
+func (d *Datum) ProjJSON ( ctx *Context, opts ...string ) string {
+    return toProjJSON(ctx, d, opts)
+}
+
+// Datum returns the datum of the reference system.
+//
+func (crs *ReferenceSystem) Datum ( ctx *Context ) ( *Datum, error ) {
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    pj := C.proj_crs_get_datum((*ctx).pj, (*crs).pj)
+    if pj == nil {
+        return nil, contextError(ctx, "Datum", crs.String(), ErrInvalidDefinition)
+    }
+    return newDatum(ctx, pj), nil
+}
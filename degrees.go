@@ -0,0 +1,173 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+// DegreeOperation wraps an operation so that angular coordinates are given
+// and returned in degrees : the conversion from and to radians is only done
+// when the operation expects or returns radians (see
+// `Operation.AngularInput` and `Operation.AngularOutput`). Other coordinates,
+// as well as the ones of operations working in the CRS' native angular
+// unit (e.g. created from "EPSG:4326"), are left untouched. `Factors` also
+// works in degrees.
+//
+//   utm, _ := NewOperation(ctx, nil, "+proj=utm +zone=32 +ellps=GRS80")
+//   i, _ := utm.InDegrees().Transform(Forward, NewCoordinate(12, 55))
+//
+type DegreeOperation struct {
+    *Operation
+}
+
+// InDegrees returns the operation working in degrees.
+//
+func (op *Operation) InDegrees () *DegreeOperation {
+    return &DegreeOperation{Operation:op}
+}
+
+// scale2D multiplies the first two components of the coordinate by `k`.
+//
+func (c *Coordinate) scale2D ( k float64 ) {
+    x, y, z, t := c.Components4D()
+    (*c).pj = C.proj_coord(C.double(x*k), C.double(y*k), C.double(z), C.double(t))
+}
+
+// toRadians converts the coordinates from degrees when the operation
+// expects radians.
+//
+func (dop *DegreeOperation) toRadians ( d Direction, cs []Coordinate ) {
+    if dop.AngularInput(d) {
+        for i := range cs {
+            cs[i].scale2D(DegToRad)
+        }
+    }
+}
+
+// toDegrees converts the coordinates to degrees when the operation returns
+// radians.
+//
+func (dop *DegreeOperation) toDegrees ( d Direction, cs []Coordinate ) {
+    if dop.AngularOutput(d) {
+        for i := range cs {
+            cs[i].scale2D(RadToDeg)
+        }
+    }
+}
+
+// Transform applies the transformation of coordinates, in degrees, to
+// object implementing `Locatable` either from or to the CRS.
+// Returns the object with transformed coordinates or nil on error.
+//
+func (dop *DegreeOperation) Transform ( d Direction, c Locatable ) ( r Locatable, e error ) {
+    xyzt := []Coordinate{*c.Location()}
+    dop.toRadians(d, xyzt)
+    if _, e = dop.fwdinv(d, &xyzt[0]) ; e != nil {
+        return
+    }
+    dop.toDegrees(d, xyzt)
+    c.SetLocation(&xyzt[0])
+    r = c
+    return
+}
+
+// TransformArray applies the transformation of coordinates, in degrees, to
+// the array either from or to the CRS.
+// Returns the array with transformed coordinates or nil on error.
+//
+func (dop *DegreeOperation) TransformArray ( d Direction, c []Coordinate ) ( r []Coordinate, e error ) {
+    buf := make([]Coordinate, len(c))
+    copy(buf, c)
+    dop.toRadians(d, buf)
+    if _, e = dop.fwdinv_array(d, buf) ; e != nil {
+        return
+    }
+    dop.toDegrees(d, buf)
+    copy(c, buf)
+    r = c
+    return
+}
+
+// TransformBatch applies the transformation of coordinates, in degrees, to
+// a collection implementing `LocatableBatch` either from or to the CRS.
+// Returns the collection with transformed coordinates or nil on error.
+//
+func (dop *DegreeOperation) TransformBatch ( d Direction, b LocatableBatch ) ( r LocatableBatch, e error ) {
+    buf := make([]Coordinate, b.Len())
+    b.Coordinates(buf)
+    dop.toRadians(d, buf)
+    if _, e = dop.fwdinv_array(d, buf) ; e != nil {
+        return
+    }
+    dop.toDegrees(d, buf)
+    b.SetCoordinates(buf)
+    r = b
+    return
+}
+
+// DegreeFactors holds the cartographic properties computed by
+// `DegreeOperation.Factors`, angles being in degrees and partial
+// derivatives per degree.
+//
+type DegreeFactors struct {
+    *Factors
+}
+
+// Factors creates various cartographic properties of the coordinate, in
+// degrees, such as scale factors, angular distortion and meridian
+// convergence (see `Operation.Factors`).
+//
+func (dop *DegreeOperation) Factors ( c *Coordinate ) ( *DegreeFactors, error ) {
+    xyzt := []Coordinate{*c}
+    dop.toRadians(Forward, xyzt)
+    f, e := dop.Operation.Factors(&xyzt[0])
+    if e != nil {
+        return nil, e
+    }
+    return &DegreeFactors{Factors:f}, nil
+}
+
+// AngularDistortion returns the angular distortion in degrees.
+//
+func (f *DegreeFactors) AngularDistortion ( ) float64 {
+    return (*f).Factors.AngularDistortion() * RadToDeg
+}
+
+// MeridianParallelAngle returns the meridian/parallel angle, θ′, in
+// degrees.
+//
+func (f *DegreeFactors) MeridianParallelAngle ( ) float64 {
+    return (*f).Factors.MeridianParallelAngle() * RadToDeg
+}
+
+// MeridianConvergence returns the meridian convergence in degrees.
+//
+func (f *DegreeFactors) MeridianConvergence ( ) float64 {
+    return (*f).Factors.MeridianConvergence() * RadToDeg
+}
+
+// PartialDerivativeXλ returns the partial derivative ∂x/∂λ, λ in degrees.
+//
+func (f *DegreeFactors) PartialDerivativeXλ ( ) float64 {
+    return (*f).Factors.PartialDerivativeXλ() * DegToRad
+}
+
+// PartialDerivativeYλ returns the partial derivative ∂y/∂λ, λ in degrees.
+//
+func (f *DegreeFactors) PartialDerivativeYλ ( ) float64 {
+    return (*f).Factors.PartialDerivativeYλ() * DegToRad
+}
+
+// PartialDerivativeXφ returns the partial derivative ∂x/∂φ, φ in degrees.
+//
+func (f *DegreeFactors) PartialDerivativeXφ ( ) float64 {
+    return (*f).Factors.PartialDerivativeXφ() * DegToRad
+}
+
+// PartialDerivativeYφ returns the partial derivative ∂y/∂φ, φ in degrees.
+//
+func (f *DegreeFactors) PartialDerivativeYφ ( ) float64 {
+    return (*f).Factors.PartialDerivativeYφ() * DegToRad
+}
@@ -0,0 +1,70 @@
+package proj
+
+import (
+    "testing"
+    "math"
+)
+
+// Tests :
+
+// TestDegreeOperation checks degrees are converted only when needed.
+func TestDegreeOperation ( t *testing.T ) {
+    utm, e := NewOperation(ctx, nil, "+proj=utm +zone=31 +ellps=WGS84")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer utm.DestroyOperation()
+    if !utm.AngularInput(Forward) || utm.AngularOutput(Forward) {
+        t.Errorf("Expected angular input and linear output in forward direction")
+    }
+    if utm.AngularInput(Inverse) || !utm.AngularOutput(Inverse) {
+        t.Errorf("Expected linear input and angular output in inverse direction")
+    }
+    dop := utm.InDegrees()
+    i, e := dop.Transform(Forward, NewCoordinate(3.0, 0.0))
+    if e != nil {
+        t.Fatal(e)
+    }
+    c := i.(*Coordinate)
+    if math.Abs(c.E() - 500000.0) > 1e-6 || math.Abs(c.N()) > 1e-6 {
+        t.Errorf("Expected (500000.0, 0.0), but got (%.1f, %.1f)", c.E(), c.N())
+    }
+    cs, e := dop.TransformArray(Inverse, []Coordinate{*c})
+    if e != nil {
+        t.Fatal(e)
+    }
+    if math.Abs(cs[0].X() - 3.0) > 1e-9 || math.Abs(cs[0].Y()) > 1e-9 {
+        t.Errorf("Expected (3.0, 0.0), but got (%f, %f)", cs[0].X(), cs[0].Y())
+    }
+    rf, e := utm.Factors(NewCoordinate(4.0*DegToRad, 45.0*DegToRad))
+    if e != nil {
+        t.Fatal(e)
+    }
+    df, e := dop.Factors(NewCoordinate(4.0, 45.0))
+    if e != nil {
+        t.Fatal(e)
+    }
+    if math.Abs(df.MeridianConvergence() - rf.MeridianConvergence()*RadToDeg) > 1e-9 || math.Abs(df.MeridianConvergence()) < 0.5 {
+        t.Errorf("Expected a meridian convergence of %f degrees, but got %f", rf.MeridianConvergence()*RadToDeg, df.MeridianConvergence())
+    }
+    if math.Abs(df.MeridionalScale() - rf.MeridionalScale()) > 1e-12 {
+        t.Errorf("Expected a meridional scale of %f, but got %f", rf.MeridionalScale(), df.MeridionalScale())
+    }
+    // EPSG:4326 is already in degrees :
+    o, e := NewOperation(ctx, &Area{}, "EPSG:4326", "EPSG:32631")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer o.DestroyOperation()
+    if o.AngularInput(Forward) {
+        t.Errorf("Unexpected angular input for EPSG:4326 to EPSG:32631")
+    }
+    i, e = o.InDegrees().Transform(Forward, NewCoordinate(0.0, 3.0))
+    if e != nil {
+        t.Fatal(e)
+    }
+    c = i.(*Coordinate)
+    if math.Abs(c.E() - 500000.0) > 1e-6 || math.Abs(c.N()) > 1e-6 {
+        t.Errorf("Expected (500000.0, 0.0), but got (%.1f, %.1f)", c.E(), c.N())
+    }
+}
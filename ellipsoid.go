@@ -8,13 +8,15 @@ package proj
 import "C"
 
 import (
-    "fmt"
+    "runtime"
 )
 
 // Ellipsoid contains an internal object that holds everything related to a
 // given ellipsoid.
 type Ellipsoid struct {
-    pj *C.PJ
+    pj  *C.PJ
+    ctx *Context    // owning context
+    use usage       // concurrent use tracker
 }
 
 // NewEllipsoid creates an ellipsoid from a WKT string or a URI.
@@ -26,23 +28,43 @@ func NewEllipsoid (ctx *Context, def string ) ( ell *Ellipsoid, e error ) {
         if C.proj_get_type(pj) != C.PJ_TYPE_ELLIPSOID {
             C.proj_destroy(pj)
             pj = nil
-            e = fmt.Errorf("%v does not yield an Ellipsoid", def)
+            e = newError(ctx, "NewEllipsoid", def, ErrNotAnEllipsoid, "does not yield an Ellipsoid")
             return
         }
-        ell = &Ellipsoid{pj:pj}
+        ell = newEllipsoid(ctx, pj)
     }
     return
 }
 
+// newEllipsoid wraps the PROJ pointer created in the given context.
+//
+func newEllipsoid ( ctx *Context, pj *C.PJ ) *Ellipsoid {
+    ell := &Ellipsoid{pj:pj, ctx:ctx}
+    allocated()
+    runtime.SetFinalizer(ell, func ( ell *Ellipsoid ) {
+        leaked(ell)
+        destroyPJ(nil, (*ell).pj)
+    })
+    return ell
+}
+
 // DestroyEllipsoid deallocate the internal ellipsoid object.
 //
 func (ell *Ellipsoid) DestroyEllipsoid () {
     if (*ell).pj != nil {
-        C.proj_destroy((*ell).pj)
+        destroyPJ((*ell).ctx, (*ell).pj)
         (*ell).pj = nil
+        runtime.SetFinalizer(ell, nil)
     }
 }
 
+// Close deallocates the internal Ellipsoid object. It implements io.Closer.
+//
+func (ell *Ellipsoid) Close () error {
+    ell.DestroyEllipsoid()
+    return nil
+}
+
 // Handle returns the PROJ internal object to be passed to the PROJ library
 // Cannot be tested against nil as it returns a pointer to a type, so use :
 //   if p.HandleIsNil() { ... }
@@ -57,6 +79,46 @@ func (ell *Ellipsoid)  HandleIsNil () bool {
     return (*ell).pj == (*C.PJ)(nil)
 }
 
+// Context returns the context owning the ellipsoid.
+//
+func (ell *Ellipsoid) Context () *Context {
+    return (*ell).ctx
+}
+
+// guard returns the concurrent use tracker of the ellipsoid.
+//
+func (ell *Ellipsoid) guard () *usage {
+    return &((*ell).use)
+}
+
+// Clone duplicates the ellipsoid into the given context. The owning context
+// is used when `ctx` is nil.
+//
+func (ell *Ellipsoid) Clone ( ctx *Context ) ( c *Ellipsoid, e error ) {
+    if ctx == nil {
+        ctx = (*ell).ctx
+    }
+    defer ell.use.enter(ell, nil, nil)()
+    defer runtime.KeepAlive(ell)
+    var pj *C.PJ
+    if pj, e = clonePJ(ctx, (*ell).pj, "Ellipsoid") ; e != nil {
+        return
+    }
+    c = newEllipsoid(ctx, pj)
+    return
+}
+
+// AssignContext moves the ellipsoid to the given context, which then owns
+// it. Must not be called while the ellipsoid is in use. It panics when `ctx`
+// is nil.
+//
+func (ell *Ellipsoid) AssignContext ( ctx *Context ) {
+    defer ell.use.enter(ell, nil, nil)()
+    defer runtime.KeepAlive(ell)
+    C.proj_assign_context((*ell).pj, (*ctx).pj)
+    (*ell).ctx = ctx
+}
+
 // TypeOf returns the ISOType of an ellipsoid (EllipsoidType).
 // UnKnownType on error.
 //
@@ -67,6 +129,8 @@ func (ell *Ellipsoid) TypeOf ( ) ISOType {
 // SemiMajor returns the semi-major axis in meter of the given ellipsoid.
 //
 func (ell *Ellipsoid) SemiMajor ( ctx *Context ) ( a float64, e error ) {
+    defer ell.use.enter(ell, (*ell).ctx, ctx)()
+    defer runtime.KeepAlive(ell)
     _ = C.proj_errno_reset((*ell).pj)
     var ca C.double
     // proj_ellipsoid_get_parameters fails if ell is not an ellipsoid ...
@@ -79,6 +143,8 @@ func (ell *Ellipsoid) SemiMajor ( ctx *Context ) ( a float64, e error ) {
 // computed or defined of the given ellipsoid.
 //
 func (ell *Ellipsoid) SemiMinor ( ctx *Context ) ( b float64, bIsComputed bool, e error ) {
+    defer ell.use.enter(ell, (*ell).ctx, ctx)()
+    defer runtime.KeepAlive(ell)
     _ = C.proj_errno_reset((*ell).pj)
     var cb C.double
     var cbic C.int
@@ -92,6 +158,8 @@ func (ell *Ellipsoid) SemiMinor ( ctx *Context ) ( b float64, bIsComputed bool,
 // InverseFlattening returns the inverse flattening of the given ellipsoid.
 //
 func (ell *Ellipsoid) InverseFlattening ( ctx *Context ) ( rf float64, e error ) {
+    defer ell.use.enter(ell, (*ell).ctx, ctx)()
+    defer runtime.KeepAlive(ell)
     _ = C.proj_errno_reset((*ell).pj)
     var crf C.double
     // proj_ellipsoid_get_parameters fails if ell is not an ellipsoid ...
@@ -104,6 +172,8 @@ func (ell *Ellipsoid) InverseFlattening ( ctx *Context ) ( rf float64, e error )
 // semi-minor is computed and the inverse flattening of the given ellipsoid.
 //
 func (ell *Ellipsoid) Parameters ( ctx *Context ) ( a float64, b float64, bIsComputed bool, rf float64, e error ) {
+    defer ell.use.enter(ell, (*ell).ctx, ctx)()
+    defer runtime.KeepAlive(ell)
     _ = C.proj_errno_reset((*ell).pj)
     var ca, cb, crf C.double
     var cbic C.int
@@ -119,6 +189,7 @@ func (ell *Ellipsoid) Parameters ( ctx *Context ) ( a float64, b float64, bIsCom
 // Info returns information about a specific ellipsoid object.
 //
 func (ell *Ellipsoid) Info ( ) ( *ISOInfo ) {
+    defer runtime.KeepAlive(ell)
     return &ISOInfo{pj:C.proj_pj_info((*ell).pj)}
 }
 
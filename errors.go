@@ -0,0 +1,153 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "errors"
+    "strings"
+)
+
+// Sentinel errors, to be checked with `errors.Is` against the errors
+// returned by the wrapper :
+//
+//   if _, e := NewReferenceSystem(ctx, "EPSG:0") ; errors.Is(e, ErrUnknownAuthorityCode) { ... }
+//
+var (
+    // ErrNoOperation no operation found between two reference systems
+    ErrNoOperation          = errors.New("no operation found")
+    // ErrGridNotFound a grid needed by an operation cannot be found
+    ErrGridNotFound         = errors.New("grid not found")
+    // ErrInvalidCoordinate a coordinate cannot be transformed
+    ErrInvalidCoordinate    = errors.New("invalid coordinate")
+    // ErrInvalidDefinition a definition cannot be parsed
+    ErrInvalidDefinition    = errors.New("invalid definition")
+    // ErrUnknownAuthorityCode an object code is unknown to its authority
+    ErrUnknownAuthorityCode = errors.New("unknown authority code")
+    // ErrNotACRS the definition does not yield a reference system
+    ErrNotACRS              = errors.New("not a CRS")
+    // ErrNotAnOperation the definition does not yield an operation
+    ErrNotAnOperation       = errors.New("not an operation")
+    // ErrNotAnEllipsoid the definition does not yield an ellipsoid
+    ErrNotAnEllipsoid       = errors.New("not an ellipsoid")
+    // ErrNotAPrimeMeridian the definition does not yield a prime meridian
+    ErrNotAPrimeMeridian    = errors.New("not a prime meridian")
+    // ErrInvalidParameter a parameter of an object to create is out of range
+    ErrInvalidParameter     = errors.New("invalid parameter")
+)
+
+// PROJ error numbers with a sentinel error (see pj_strerrno.c)
+//
+const (
+    errnoLatOrLonExceedLimit    = -14   // latitude or longitude exceeded limits
+    errnoInvalidXOrY            = -15   // invalid x or y
+    errnoAcosAsinArgTooLarge    = -19   // acos/asin: |arg| >1.+1e-14
+    errnoToleranceCondition     = -20   // tolerance condition error
+    errnoFailedToLoadGrid       = -38   // failed to load datum shift file
+    errnoGridAreaOutOfBounds    = -48   // point not within available datum shift grids
+)
+
+// ProjError holds a failure of the PROJ library or of the wrapper.
+//
+type ProjError struct {
+    // Errno is the PROJ error number, 0 when the failure is detected by the
+    // wrapper
+    Errno   int
+    // Op is the name of the failing function, e.g. "NewReferenceSystem"
+    Op      string
+    // Def is the definition or the object involved, if any
+    Def     string
+    // Context is the context in which the failure occurred
+    Context *Context
+    // Err is the sentinel error matching the failure, nil if none
+    Err     error
+    // Msg is the message describing the failure
+    Msg     string
+    // Messages holds the error messages PROJ logged during the failing call,
+    // often more detailed than Msg. It is not filled by `Transform` that
+    // runs once per coordinate.
+    Messages []string
+}
+
+// Error returns the text representation of the failure, followed by the
+// messages logged by PROJ, e.g.
+// "NewReferenceSystem 'PSG:4326': unknown authority code (proj_create: crs not found)".
+//
+func (e *ProjError) Error () string {
+    var b strings.Builder
+    b.WriteString(e.Op)
+    if e.Def != "" {
+        b.WriteString(" '")
+        b.WriteString(e.Def)
+        b.WriteString("'")
+    }
+    b.WriteString(": ")
+    switch {
+    case e.Msg != "" :
+        b.WriteString(e.Msg)
+    case e.Err != nil :
+        b.WriteString(e.Err.Error())
+    default :
+        b.WriteString("unknown error")
+    }
+    if len(e.Messages) > 0 {
+        b.WriteString(" (")
+        b.WriteString(strings.Join(e.Messages, "; "))
+        b.WriteString(")")
+    }
+    return b.String()
+}
+
+// Unwrap returns the sentinel error matching the failure.
+//
+func (e *ProjError) Unwrap () error {
+    return e.Err
+}
+
+// errnoSentinel returns the sentinel error matching a PROJ error number,
+// nil if none.
+//
+func errnoSentinel ( errno int ) error {
+    switch errno {
+    case errnoFailedToLoadGrid :
+        return ErrGridNotFound
+    case errnoLatOrLonExceedLimit,
+         errnoInvalidXOrY,
+         errnoAcosAsinArgTooLarge,
+         errnoToleranceCondition,
+         errnoGridAreaOutOfBounds :
+        return ErrInvalidCoordinate
+    default :
+        return nil
+    }
+}
+
+// newError creates an error detected by the wrapper.
+//
+func newError ( ctx *Context, op string, def string, sentinel error, msg string ) *ProjError {
+    return &ProjError{Op:op, Def:def, Context:ctx, Err:sentinel, Msg:msg}
+}
+
+// errnoError creates an error from a PROJ error number. `sentinel` is used
+// when the error number has no matching sentinel error.
+//
+func errnoError ( ctx *Context, op string, def string, errno C.int, sentinel error ) *ProjError {
+    e := &ProjError{Errno:int(errno), Op:op, Def:def, Context:ctx, Err:errnoSentinel(int(errno))}
+    if e.Err == nil {
+        e.Err = sentinel
+    }
+    if errno != C.int(0) {
+        e.Msg = C.GoString(C.proj_errno_string(errno))
+    }
+    return e
+}
+
+// contextError creates an error from the PROJ error number of the context.
+//
+func contextError ( ctx *Context, op string, def string, sentinel error ) *ProjError {
+    return errnoError(ctx, op, def, C.proj_context_errno((*ctx).pj), sentinel)
+}
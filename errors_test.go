@@ -0,0 +1,112 @@
+package proj
+
+import (
+    "testing"
+    "errors"
+)
+
+// Tests :
+
+// TestProjError checks the text representation and unwrapping of errors.
+func TestProjError ( t *testing.T ) {
+    e := newError(ctx, "NewReferenceSystem", "PSG:4326", ErrUnknownAuthorityCode, "")
+    if e.Error() != "NewReferenceSystem 'PSG:4326': unknown authority code" {
+        t.Errorf("Unexpected error text '%s'", e.Error())
+    }
+    if !errors.Is(e, ErrUnknownAuthorityCode) {
+        t.Errorf("Expected '%v' to be ErrUnknownAuthorityCode", e)
+    }
+    if errors.Is(e, ErrNotACRS) {
+        t.Errorf("Unexpected '%v' to be ErrNotACRS", e)
+    }
+    e = errnoError(ctx, "Transform", "", errnoFailedToLoadGrid, nil)
+    if !errors.Is(e, ErrGridNotFound) || e.Errno != errnoFailedToLoadGrid || e.Msg == "" {
+        t.Errorf("Expected '%v' to be ErrGridNotFound", e)
+    }
+    e = errnoError(ctx, "Transform", "", errnoLatOrLonExceedLimit, nil)
+    if !errors.Is(e, ErrInvalidCoordinate) {
+        t.Errorf("Expected '%v' to be ErrInvalidCoordinate", e)
+    }
+}
+
+// TestTypedErrors checks constructors and transformations return typed
+// errors.
+func TestTypedErrors ( t *testing.T ) {
+    var pe *ProjError
+    _, e := NewReferenceSystem(ctx, "EPSG:0")
+    if !errors.Is(e, ErrUnknownAuthorityCode) {
+        t.Errorf("Expected ErrUnknownAuthorityCode, got '%v'", e)
+    }
+    if !errors.As(e, &pe) || pe.Context != ctx || pe.Def != "EPSG:0" {
+        t.Errorf("Expected a ProjError for 'EPSG:0', got '%v'", e)
+    }
+    _, e = NewReferenceSystem(ctx, "urn:ogc:def:coordinateOperation:EPSG::1671")
+    if !errors.Is(e, ErrNotACRS) {
+        t.Errorf("Expected ErrNotACRS, got '%v'", e)
+    }
+    // no coordinate operation EPSG:4326 in the database :
+    _, e = NewOperation(ctx, nil, "EPSG:4326")
+    if !errors.Is(e, ErrUnknownAuthorityCode) || errors.Is(e, ErrNotAnOperation) {
+        t.Errorf("Expected ErrUnknownAuthorityCode, got '%v'", e)
+    }
+    // a CRS is found, but it is not an operation :
+    _, e = NewOperation(ctx, nil, "urn:ogc:def:crs:EPSG::4326")
+    if !errors.Is(e, ErrNotAnOperation) || errors.Is(e, ErrUnknownAuthorityCode) {
+        t.Errorf("Expected ErrNotAnOperation, got '%v'", e)
+    }
+    _, e = NewEllipsoid(ctx, "GRS80")
+    if !errors.Is(e, ErrNotAnEllipsoid) {
+        t.Errorf("Expected ErrNotAnEllipsoid, got '%v'", e)
+    }
+    op, e := NewOperation(ctx, nil, "+proj=utm +zone=32 +ellps=GRS80")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer op.DestroyOperation()
+    _, e = op.Transform(Forward, NewCoordinate(0.0, 100.0))
+    if !errors.Is(e, ErrInvalidCoordinate) {
+        t.Errorf("Expected ErrInvalidCoordinate, got '%v'", e)
+    }
+    if !errors.As(e, &pe) || pe.Errno == 0 || pe.Op != "Transform" {
+        t.Errorf("Expected a ProjError with an errno, got '%v'", e)
+    }
+}
+
+// TestErrorMessages checks messages logged by PROJ during a failing call
+// are attached to the error without reaching a handler that did not ask
+// for them.
+func TestErrorMessages ( t *testing.T ) {
+    c := NewContext()
+    defer c.DestroyContext()
+    var logged []string
+    c.SetLogger(func ( ctx *Context, lvl LoggingLevel, msg string ) {
+        logged = append(logged, msg)
+    })
+    _, e := NewOperation(c, nil, "+proj=UnKnownProjection")
+    var pe *ProjError
+    if !errors.As(e, &pe) {
+        t.Fatalf("Expected a *ProjError, but got '%v'", e)
+    }
+    if len(pe.Messages) == 0 {
+        t.Errorf("Expected messages attached to '%v'", e)
+    }
+    if len(logged) != 0 {
+        t.Errorf("Unexpected messages logged with level None : %v", logged)
+    }
+    if c.LogLevel() != None {
+        t.Errorf("Expected log level to be restored to None, but got %s", c.LogLevel())
+    }
+    c.SetLogLevel(Error)
+    _, e = NewReferenceSystem(c, "+proj=UnKnownProjection +type=crs")
+    if !errors.As(e, &pe) || len(pe.Messages) == 0 {
+        t.Errorf("Expected messages attached to '%v'", e)
+    }
+    if len(logged) == 0 {
+        t.Errorf("Expected messages logged with level Error")
+    }
+    crs, e := NewReferenceSystem(c, "EPSG:4326")
+    if e != nil {
+        t.Fatal(e)
+    }
+    crs.DestroyReferenceSystem()
+}
@@ -308,3 +308,69 @@ func ExampleOperation_locatablearray () {
 
 }
 
+
+// Surveys implements LocatableBatch interface
+type Surveys []XYQS
+func (s Surveys) Len () int {
+    return len(s)
+}
+func (s Surveys) Coordinates ( buf []Coordinate ) {
+    for i := range s {
+        buf[i] = *s[i].Location()
+    }
+}
+func (s Surveys) SetCoordinates ( buf []Coordinate ) {
+    for i := range s {
+        s[i].SetLocation(&buf[i])
+    }
+}
+
+func ExampleOperation_locatablebatch () {
+    c := NewContext()
+    defer c.DestroyContext()
+    lcc, _ := NewOperation(c, nil, epsg2154PROJString)
+    defer lcc.DestroyOperation()
+
+    // switch to radians ...
+    surveys := make(Surveys, 365)
+    for r := range surveys {
+        surveys[r] = XYQS{x:2.3488000*DegToRad, y:48.8534100*DegToRad, qualityLevel:1, surveyorName:[]byte("me")}
+    }
+    // a single call to PROJ for the whole collection :
+    if _, e := lcc.TransformBatch(Forward, surveys) ; e != nil {
+        fmt.Println(e)
+        return
+    }
+    fmt.Printf("easting: %.5f, northing: %.5f\n", surveys[  0].x, surveys[  0].y)
+    fmt.Printf("easting: %.5f, northing: %.5f\n", surveys[364].x, surveys[364].y)
+
+    // Output:
+    // easting: 652.21664, northing: 6861.68261
+    // easting: 652.21664, northing: 6861.68261
+}
+
+func ExampleDegreeOperation () {
+    c := NewContext()
+    defer c.DestroyContext()
+
+    utm, _ := NewOperation(c, nil, utm32PROJString)
+    if utm == nil {
+        fmt.Println("Oops (utm)")
+        return
+    }
+    defer utm.DestroyOperation()
+    // a coordinate representing Copenhagen: 55d N, 12d E, straight from GPS
+    // data : no need for DegToRad
+    dop := utm.InDegrees()
+    i, _ := dop.Transform(Forward, NewCoordinate(12, 55))
+    b := i.(*Coordinate)
+    fmt.Printf("easting: %.2f, northing: %.2f\n", b.E(), b.N())
+
+    i, _ = dop.Transform(Inverse, b)
+    b = i.(*Coordinate)
+    fmt.Printf("longitude: %.6f, latitude: %.6f\n", b.X(), b.Y())
+
+    // Output:
+    // easting: 691875.63, northing: 6098907.83
+    // longitude: 12.000000, latitude: 55.000000
+}
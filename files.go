@@ -0,0 +1,200 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "runtime"
+    "unsafe"
+)
+
+// SearchPaths returns the paths assigned with SetSearchPaths, nil if none.
+//
+func (ctx *Context) SearchPaths () []string {
+    if (*ctx).searchPaths == nil {
+        return nil
+    }
+    return append([]string(nil), (*ctx).searchPaths...)
+}
+
+// SetSearchPaths assigns the directories where PROJ looks for resource files
+// (grids, init files, proj.db). They take precedence over the PROJ_LIB
+// environment variable. An empty list reverts to the default search.
+//
+func (ctx *Context) SetSearchPaths ( paths []string ) {
+    defer runtime.KeepAlive(ctx)
+    l := len(paths)
+    if l == 0 {
+        C.proj_context_set_search_paths((*ctx).pj, 0, nil)
+        (*ctx).searchPaths = nil
+        (*ctx).authorities = nil
+        (*ctx).settings++
+        return
+    }
+    cpaths := C.makeStringArray(C.size_t(l))
+    for i, p := range paths {
+        C.setStringArrayItem(cpaths, C.size_t(i), C.CString(p))
+    }
+    C.proj_context_set_search_paths((*ctx).pj, C.int(l), cpaths)
+    for i := 0 ; i < l ; i++ {
+        C.free(unsafe.Pointer(C.getStringArrayItem(cpaths, C.size_t(i))))
+    }
+    C.destroyStringArray(&cpaths)
+    (*ctx).searchPaths = append([]string(nil), paths...)
+    (*ctx).authorities = nil
+    (*ctx).settings++
+}
+
+// SetFileFinder assigns the function PROJ calls to locate a resource file
+// (grid, init file, proj.db) before searching its usual locations. The
+// function returns the path to the file and true, or false to let PROJ
+// search on. A nil function removes the finder.
+//
+//   ctx.SetFileFinder(func ( name string ) ( string, bool ) {
+//       p := filepath.Join("/opt/grids", name)
+//       _, e := os.Stat(p)
+//       return p, e == nil
+//   })
+//
+func (ctx *Context) SetFileFinder ( finder func ( name string ) ( string, bool ) ) {
+    ctx.setFinder(finder, nil)
+}
+
+// setFinder installs either the finder function or the file system finder,
+// or removes the finder when both are nil.
+//
+func (ctx *Context) setFinder ( finder func ( name string ) ( string, bool ), fsys fs.FS ) {
+    defer runtime.KeepAlive(ctx)
+    (*ctx).finder = finder
+    (*ctx).fsys = fsys
+    (*ctx).authorities = nil
+    (*ctx).settings++
+    if finder == nil && fsys == nil {
+        C.setFileFinder((*ctx).pj, 0)
+        return
+    }
+    C.setFileFinder((*ctx).pj, C.uintptr_t((*ctx).id))
+}
+
+// SetFileSystem serves resource files (grids, init files, proj.db) from the
+// file system (e.g. an embed.FS) through the file finder of the context. As
+// PROJ only reads files from disk, the requested files are extracted once in
+// a temporary directory removed when the context is destroyed. It replaces
+// the finder assigned with SetFileFinder; a nil file system removes it.
+// Call `SetDatabasePath("")` afterwards to load proj.db from the file system.
+//
+//   //go:embed grids
+//   var grids embed.FS
+//   ...
+//   sub, _ := fs.Sub(grids, "grids")
+//   ctx.SetFileSystem(sub)
+//
+func (ctx *Context) SetFileSystem ( fsys fs.FS ) {
+    ctx.setFinder(nil, fsys)
+}
+
+// extractFile copies the named file from the file system into the cache
+// directory of the context, and returns its path.
+//
+func (ctx *Context) extractFile ( fsys fs.FS, name string ) ( string, bool ) {
+    if !fs.ValidPath(name) {
+        return "", false
+    }
+    if fi, e := fs.Stat(fsys, name) ; e != nil || fi.IsDir() {
+        return "", false
+    }
+    if (*ctx).cacheDir == "" {
+        d, e := os.MkdirTemp("", "proj-")
+        if e != nil {
+            LogOnError(e)
+            return "", false
+        }
+        (*ctx).cacheDir = d
+    }
+    p := filepath.Join((*ctx).cacheDir, filepath.FromSlash(name))
+    if _, e := os.Stat(p) ; e == nil {
+        return p, true
+    }
+    if e := copyFile(fsys, name, p) ; e != nil {
+        LogOnError(e)
+        return "", false
+    }
+    return p, true
+}
+
+// copyFile copies the named file from the file system to path `p`.
+//
+func copyFile ( fsys fs.FS, name string, p string ) error {
+    src, e := fsys.Open(name)
+    if e != nil {
+        return e
+    }
+    defer src.Close()
+    if e = os.MkdirAll(filepath.Dir(p), 0o755) ; e != nil {
+        return e
+    }
+    tmp, e := os.CreateTemp(filepath.Dir(p), ".extract-")
+    if e != nil {
+        return e
+    }
+    if _, e = io.Copy(tmp, src) ; e != nil {
+        tmp.Close()
+        os.Remove(tmp.Name())
+        return e
+    }
+    if e = tmp.Close() ; e != nil {
+        os.Remove(tmp.Name())
+        return e
+    }
+    return os.Rename(tmp.Name(), p)
+}
+
+// releaseFiles frees the resources held by the file finder.
+//
+func (ctx *Context) releaseFiles () {
+    (*ctx).finder = nil
+    (*ctx).fsys = nil
+    if (*ctx).found != nil {
+        C.free(unsafe.Pointer((*ctx).found))
+        (*ctx).found = nil
+    }
+    if (*ctx).cacheDir != "" {
+        os.RemoveAll((*ctx).cacheDir)
+        (*ctx).cacheDir = ""
+    }
+}
+
+// findFileOnC is called by PROJ (through `fileFinderToGo`) to locate a
+// resource file. The returned string stays valid until the next call.
+//
+//export findFileOnC
+func findFileOnC ( id C.uintptr_t, name *C.char ) *C.char {
+    ctx := lookup(uintptr(id))
+    if ctx == nil || name == nil {
+        return nil
+    }
+    var p string
+    var ok bool
+    switch {
+    case (*ctx).fsys != nil :
+        p, ok = ctx.extractFile((*ctx).fsys, C.GoString(name))
+    case (*ctx).finder != nil :
+        p, ok = (*ctx).finder(C.GoString(name))
+    }
+    if !ok {
+        return nil
+    }
+    if (*ctx).found != nil {
+        C.free(unsafe.Pointer((*ctx).found))
+    }
+    (*ctx).found = C.CString(p)
+    return (*ctx).found
+}
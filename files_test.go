@@ -0,0 +1,94 @@
+package proj
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+    "testing/fstest"
+)
+
+// Tests :
+
+var (
+    initFile = []byte("<merc> +proj=merc +ellps=WGS84 <>\n")
+)
+
+// TestSearchPaths checks the search paths are kept by the context.
+func TestSearchPaths ( t *testing.T ) {
+    c := NewContext()
+    defer c.DestroyContext()
+    if c.SearchPaths() != nil {
+        t.Errorf("Expected no search paths, but got %v", c.SearchPaths())
+    }
+    dir := t.TempDir()
+    if e := os.WriteFile(filepath.Join(dir, "goinit"), initFile, 0o644) ; e != nil {
+        t.Fatal(e)
+    }
+    c.SetSearchPaths([]string{dir})
+    if !reflect.DeepEqual(c.SearchPaths(), []string{dir}) {
+        t.Errorf("Expected search paths [%s], but got %v", dir, c.SearchPaths())
+    }
+    op, e := NewOperation(c, nil, "+init=goinit:merc")
+    if e != nil {
+        t.Fatal(e)
+    }
+    op.DestroyOperation()
+    c.SetSearchPaths(nil)
+    if c.SearchPaths() != nil {
+        t.Errorf("Expected no search paths, but got %v", c.SearchPaths())
+    }
+}
+
+// TestFileFinder checks PROJ calls the Go file finder.
+func TestFileFinder ( t *testing.T ) {
+    c := NewContext()
+    defer c.DestroyContext()
+    dir := t.TempDir()
+    if e := os.WriteFile(filepath.Join(dir, "goinit"), initFile, 0o644) ; e != nil {
+        t.Fatal(e)
+    }
+    var names []string
+    c.SetFileFinder(func ( name string ) ( string, bool ) {
+        names = append(names, name)
+        if name != "goinit" {
+            return "", false
+        }
+        return filepath.Join(dir, name), true
+    })
+    op, e := NewOperation(c, nil, "+init=goinit:merc")
+    if e != nil {
+        t.Fatal(e)
+    }
+    op.DestroyOperation()
+    if len(names) == 0 {
+        t.Errorf("Expected the file finder to be called")
+    }
+    c.SetFileFinder(nil)
+    if _, e = NewOperation(c, nil, "+init=goinit:merc") ; e == nil {
+        t.Errorf("Expected an error once the file finder is removed")
+    }
+}
+
+// TestFileSystem checks files are served from a fs.FS.
+func TestFileSystem ( t *testing.T ) {
+    c := NewContext()
+    fsys := fstest.MapFS{"goinit": &fstest.MapFile{Data:initFile}}
+    c.SetFileSystem(fsys)
+    op, e := NewOperation(c, nil, "+init=goinit:merc")
+    if e != nil {
+        t.Fatal(e)
+    }
+    op.DestroyOperation()
+    dir := (*c).cacheDir
+    if dir == "" {
+        t.Fatalf("Expected the init file to be extracted")
+    }
+    if _, e = os.Stat(filepath.Join(dir, "goinit")) ; e != nil {
+        t.Errorf("Expected the init file to be extracted: %v", e)
+    }
+    c.DestroyContext()
+    if _, e = os.Stat(dir) ; !os.IsNotExist(e) {
+        t.Errorf("Expected the cache directory %s to be removed", dir)
+    }
+}
@@ -0,0 +1,243 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "fmt"
+    "math"
+    "runtime"
+    "unsafe"
+)
+
+// GeodeticDatumParams describes a geodetic datum by its components. The
+// ellipsoid is either `Ellipsoid` or, when nil, `EllipsoidName`,
+// `SemiMajor` and `InverseFlattening`.
+//
+type GeodeticDatumParams struct {
+    // Name is the name of the datum
+    Name                string
+    // Ellipsoid is the ellipsoid of the datum
+    Ellipsoid           *Ellipsoid
+    // EllipsoidName is the name of the ellipsoid when `Ellipsoid` is nil
+    EllipsoidName       string
+    // SemiMajor is the semi-major axis in meters when `Ellipsoid` is nil
+    SemiMajor           float64
+    // InverseFlattening is the inverse flattening when `Ellipsoid` is nil,
+    // 0 for a sphere
+    InverseFlattening   float64
+    // PrimeMeridian is the prime meridian of the datum, nil for Greenwich
+    PrimeMeridian       *PrimeMeridian
+}
+
+// geodeticDatum holds the C arguments describing a geodetic datum, to be
+// released once used.
+//
+type geodeticDatum struct {
+    name        *C.char
+    ellps       *C.char
+    a           C.double
+    rf          C.double
+    pm          *C.char
+    pmOffset    C.double
+    pmUnit      *C.char
+    pmFactor    C.double
+}
+
+// free releases the C strings.
+//
+func (gd *geodeticDatum) free () {
+    C.free(unsafe.Pointer((*gd).name))
+    C.free(unsafe.Pointer((*gd).ellps))
+    C.free(unsafe.Pointer((*gd).pm))
+    C.free(unsafe.Pointer((*gd).pmUnit))
+}
+
+// cGeodeticDatum checks the components of the datum and returns the C
+// arguments describing it.
+//
+func cGeodeticDatum ( ctx *Context, op string, p *GeodeticDatumParams ) ( *geodeticDatum, error ) {
+    if p == nil {
+        return nil, newError(ctx, op, "", ErrInvalidParameter, "Missing datum")
+    }
+    ellps, a, rf := (*p).EllipsoidName, (*p).SemiMajor, (*p).InverseFlattening
+    if (*p).Ellipsoid != nil {
+        var e error
+        if a, _, _, rf, e = (*p).Ellipsoid.Parameters(ctx) ; e != nil {
+            return nil, e
+        }
+        ellps = C.GoString(C.proj_get_name((*(*p).Ellipsoid).pj))
+        runtime.KeepAlive((*p).Ellipsoid)
+    }
+    if !(a > 0.0) || math.IsInf(a, 0) {
+        return nil, newError(ctx, op, (*p).Name, ErrInvalidParameter, fmt.Sprintf("Expected a positive semi-major axis, but got %v", a))
+    }
+    if rf != 0.0 && !(rf > 1.0) || math.IsInf(rf, 0) {
+        return nil, newError(ctx, op, (*p).Name, ErrInvalidParameter, fmt.Sprintf("Expected an inverse flattening greater than 1 or 0, but got %v", rf))
+    }
+    gd := &geodeticDatum{a:C.double(a), rf:C.double(rf), pmFactor:C.double(math.Pi / 180.0)}
+    if (*p).PrimeMeridian != nil {
+        lon, toRad, unit, e := (*p).PrimeMeridian.Parameters(ctx)
+        if e != nil {
+            return nil, e
+        }
+        gd.pm = C.CString(C.GoString(C.proj_get_name((*(*p).PrimeMeridian).pj)))
+        runtime.KeepAlive((*p).PrimeMeridian)
+        gd.pmOffset = C.double(lon)
+        gd.pmUnit = C.CString(unit)
+        gd.pmFactor = C.double(toRad)
+    } else {
+        gd.pm = C.CString("Greenwich")
+        gd.pmUnit = C.CString("degree")
+    }
+    gd.name = C.CString((*p).Name)
+    gd.ellps = C.CString(ellps)
+    return gd, nil
+}
+
+// NewGeographicCRS creates a geographic reference system from the
+// components of its datum and an ellipsoidal coordinate system, nil for
+// latitude and longitude in degrees, e.g. the NTF (Paris) CRS :
+//
+//   clrk, _ := GetEllipsoidEntryByID("clrk80ign")
+//   ell, _ := clrk.Ellipsoid(ctx)
+//   paris, _ := GetPrimeMeridianEntryByID("paris")
+//   pm, _ := paris.PrimeMeridian(ctx)
+//   crs, e := NewGeographicCRS(ctx, "NTF (Paris)", &GeodeticDatumParams{
+//       Name:"Nouvelle Triangulation Francaise (Paris)", Ellipsoid:ell, PrimeMeridian:pm,
+//   }, nil)
+//
+func NewGeographicCRS ( ctx *Context, name string, datum *GeodeticDatumParams, cs *CoordinateSystem ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    gd, e := cGeodeticDatum(ctx, "NewGeographicCRS", datum)
+    if e != nil {
+        return
+    }
+    defer gd.free()
+    cs, release, e := ellipsoidalCS(ctx, "NewGeographicCRS", cs)
+    if e != nil {
+        return
+    }
+    defer release()
+    defer runtime.KeepAlive(cs)
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    pj := C.proj_create_geographic_crs((*ctx).pj, cname, (*gd).name, (*gd).ellps, (*gd).a, (*gd).rf, (*gd).pm, (*gd).pmOffset, (*gd).pmUnit, (*gd).pmFactor, (*cs).pj)
+    if pj == nil {
+        e = contextError(ctx, "NewGeographicCRS", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
+
+// NewGeographicCRSFromDatum creates a geographic reference system from an
+// existing datum and an ellipsoidal coordinate system, nil for latitude and
+// longitude in degrees :
+//
+//   d, _ := NewDatum(ctx, "EPSG:6171")
+//   crs, e := NewGeographicCRSFromDatum(ctx, "RGF93 lon/lat", d, cs)
+//
+func NewGeographicCRSFromDatum ( ctx *Context, name string, datum *Datum, cs *CoordinateSystem ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    if datum.TypeOf() != GeodeticReferenceFrame && datum.TypeOf() != DynamicGeodeticReferenceFrame {
+        e = newError(ctx, "NewGeographicCRSFromDatum", name, ErrInvalidParameter, "Expected a geodetic datum")
+        return
+    }
+    cs, release, e := ellipsoidalCS(ctx, "NewGeographicCRSFromDatum", cs)
+    if e != nil {
+        return
+    }
+    defer release()
+    defer runtime.KeepAlive(cs)
+    defer datum.use.enter(datum, (*datum).ctx, ctx)()
+    defer runtime.KeepAlive(datum)
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    pj := C.proj_create_geographic_crs_from_datum((*ctx).pj, cname, (*datum).pj, (*cs).pj)
+    if pj == nil {
+        e = contextError(ctx, "NewGeographicCRSFromDatum", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
+
+// ellipsoidalCS checks the coordinate system is ellipsoidal, the default
+// one being created when nil. The returned function releases the default
+// coordinate system.
+//
+func ellipsoidalCS ( ctx *Context, op string, cs *CoordinateSystem ) ( *CoordinateSystem, func (), error ) {
+    if cs == nil {
+        cs, e := NewEllipsoidal2DCS(ctx, LatitudeLongitude, nil)
+        if e != nil {
+            return nil, nil, e
+        }
+        return cs, cs.DestroyCoordinateSystem, nil
+    }
+    if cs.Type(ctx) != EllisoidalCS {
+        return nil, nil, newError(ctx, op, "", ErrInvalidParameter, "Expected an ellipsoidal coordinate system")
+    }
+    return cs, func () {}, nil
+}
+
+// NewGeocentricCRS creates a geocentric reference system from the
+// components of its datum. `linear` is the unit of the axes, nil for meter.
+//
+func NewGeocentricCRS ( ctx *Context, name string, datum *GeodeticDatumParams, linear *Unit ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    gd, e := cGeodeticDatum(ctx, "NewGeocentricCRS", datum)
+    if e != nil {
+        return
+    }
+    defer gd.free()
+    lname, lfactor, e := unitParameter(ctx, "NewGeocentricCRS", "linear", linear, LinearUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(lname))
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    pj := C.proj_create_geocentric_crs((*ctx).pj, cname, (*gd).name, (*gd).ellps, (*gd).a, (*gd).rf, (*gd).pm, (*gd).pmOffset, (*gd).pmUnit, (*gd).pmFactor, lname, lfactor)
+    if pj == nil {
+        e = contextError(ctx, "NewGeocentricCRS", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
+
+// NewGeocentricCRSFromDatum creates a geocentric reference system from an
+// existing datum. `linear` is the unit of the axes, nil for meter.
+//
+func NewGeocentricCRSFromDatum ( ctx *Context, name string, datum *Datum, linear *Unit ) ( crs *ReferenceSystem, e error ) {
+    defer ctx.capture()(&e)
+    defer runtime.KeepAlive(ctx)
+    if datum.TypeOf() != GeodeticReferenceFrame && datum.TypeOf() != DynamicGeodeticReferenceFrame {
+        e = newError(ctx, "NewGeocentricCRSFromDatum", name, ErrInvalidParameter, "Expected a geodetic datum")
+        return
+    }
+    lname, lfactor, e := unitParameter(ctx, "NewGeocentricCRSFromDatum", "linear", linear, LinearUnit)
+    if e != nil {
+        return
+    }
+    defer C.free(unsafe.Pointer(lname))
+    defer datum.use.enter(datum, (*datum).ctx, ctx)()
+    defer runtime.KeepAlive(datum)
+    cname := C.CString(name)
+    defer C.free(unsafe.Pointer(cname))
+    pj := C.proj_create_geocentric_crs_from_datum((*ctx).pj, cname, (*datum).pj, lname, lfactor)
+    if pj == nil {
+        e = contextError(ctx, "NewGeocentricCRSFromDatum", name, ErrInvalidDefinition)
+        return
+    }
+    crs = newReferenceSystem(ctx, pj)
+    return
+}
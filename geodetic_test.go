@@ -0,0 +1,96 @@
+package proj
+
+import (
+    "errors"
+    "strings"
+    "testing"
+)
+
+// Tests :
+
+// TestGeographicCRS checks a geographic CRS built with a Paris prime
+// meridian.
+func TestGeographicCRS ( t *testing.T ) {
+    clrk, e := GetEllipsoidEntryByID("clrk80ign")
+    if e != nil {
+        t.Fatal(e)
+    }
+    ell, e := clrk.Ellipsoid(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer ell.DestroyEllipsoid()
+    paris, e := GetPrimeMeridianEntryByID("paris")
+    if e != nil {
+        t.Fatal(e)
+    }
+    pm, e := paris.PrimeMeridian(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer pm.DestroyPrimeMeridian()
+    crs, e := NewGeographicCRS(ctx, "NTF (Paris)", &GeodeticDatumParams{Name:"Nouvelle Triangulation Francaise (Paris)", Ellipsoid:ell, PrimeMeridian:pm}, nil)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    if crs.TypeOf() != Geographic2DCRS {
+        t.Errorf("Expected a geographic 2D CRS, but got %v", crs.TypeOf())
+    }
+    wkt := crs.Wkt(ctx, WKTv2r2018)
+    for _, s := range []string{`GEOGCRS["NTF (Paris)"`, `DATUM["Nouvelle Triangulation Francaise (Paris)"`, `PRIMEM["Paris"`} {
+        if !strings.Contains(wkt, s) {
+            t.Errorf("Expected '%s' in '%s'", s, wkt)
+        }
+    }
+    if json := crs.ProjJSON(ctx, "MULTILINE=NO") ; !strings.Contains(json, `"type":"GeographicCRS"`) || !strings.Contains(json, `"Paris"`) {
+        t.Errorf("Unexpected PROJJSON '%s'", json)
+    }
+    datum, e := crs.Datum(ctx)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer datum.DestroyDatum()
+    if datum.Name() != "Nouvelle Triangulation Francaise (Paris)" {
+        t.Errorf("Unexpected datum '%s'", datum.Name())
+    }
+    lonlat, e := NewEllipsoidal2DCS(ctx, LongitudeLatitude, nil)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer lonlat.DestroyCoordinateSystem()
+    crs2, e := NewGeographicCRSFromDatum(ctx, "NTF (Paris) lon/lat", datum, lonlat)
+    if e != nil {
+        t.Fatal(e)
+    }
+    crs2.DestroyReferenceSystem()
+    if _, e = NewGeographicCRS(ctx, "bad", &GeodeticDatumParams{Name:"bad", SemiMajor:6378137, InverseFlattening:0.5}, nil) ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected ErrInvalidParameter, but got %v", e)
+    }
+}
+
+// TestGeocentricCRS checks a geocentric CRS built from a and rf, and from
+// a datum of the database.
+func TestGeocentricCRS ( t *testing.T ) {
+    crs, e := NewGeocentricCRS(ctx, "Local geocentric", &GeodeticDatumParams{Name:"Local datum", EllipsoidName:"Local ellipsoid", SemiMajor:6378137, InverseFlattening:298.257222101}, nil)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    if crs.TypeOf() != GeocentricCRS {
+        t.Errorf("Expected a geocentric CRS, but got %v", crs.TypeOf())
+    }
+    if wkt := crs.Wkt(ctx, WKTv2r2018) ; !strings.Contains(wkt, `ELLIPSOID["Local ellipsoid",6378137,298.257222101`) {
+        t.Errorf("Unexpected WKT '%s'", wkt)
+    }
+    rgf93, e := NewDatum(ctx, "EPSG:6171")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer rgf93.DestroyDatum()
+    crs2, e := NewGeocentricCRSFromDatum(ctx, "RGF93 geocentric", rgf93, nil)
+    if e != nil {
+        t.Fatal(e)
+    }
+    crs2.DestroyReferenceSystem()
+}
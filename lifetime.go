@@ -0,0 +1,99 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "fmt"
+    "sync"
+    "sync/atomic"
+)
+
+var (
+    // number of PROJ objects allocated and not yet released
+    liveObjects int64
+    // not 0 when objects released by the garbage collector are logged
+    leakLogging int32
+    // context adopting the objects whose owning context has been destroyed
+    // before them
+    orphans *C.PJ_CONTEXT
+    // serializes the use of orphans, PROJ contexts not being thread-safe
+    orphansMu sync.Mutex
+)
+
+// LiveObjects returns the number of PROJ objects (contexts, reference
+// systems, operations, ellipsoids, prime meridians and areas) allocated and
+// not yet released. Mostly useful in unit tests to assert there is no leak :
+//
+//   n := LiveObjects()
+//   ... code under test ...
+//   if LiveObjects() != n { t.Errorf("leak") }
+//
+func LiveObjects () int {
+    return int(atomic.LoadInt64(&liveObjects))
+}
+
+// SetLeakLogging turns on or off the logging (through `Log()`) of PROJ
+// objects that were not closed but released by the garbage collector.
+//
+func SetLeakLogging ( on bool ) {
+    if on {
+        atomic.StoreInt32(&leakLogging, 1)
+    } else {
+        atomic.StoreInt32(&leakLogging, 0)
+    }
+}
+
+// LeakLogging returns true when leaked PROJ objects are logged.
+//
+func LeakLogging () bool {
+    return atomic.LoadInt32(&leakLogging) != 0
+}
+
+// allocated records a newly allocated PROJ object.
+//
+func allocated () {
+    atomic.AddInt64(&liveObjects, 1)
+}
+
+// released records a released PROJ object.
+//
+func released () {
+    atomic.AddInt64(&liveObjects, -1)
+}
+
+// leaked is called by the finalizers before releasing the PROJ memory of an
+// object that was not closed.
+//
+func leaked ( o interface{} ) {
+    if LeakLogging() {
+        LogOnError(fmt.Errorf("%T %p was not closed", o, o))
+    }
+}
+
+// destroyPJ releases a PROJ object, handing it over to the orphans' context
+// when its owning context has already been destroyed. Finalizers pass a nil
+// owner : they run on their own goroutine while the owning context may be
+// in use on another one.
+//
+func destroyPJ ( owner *Context, pj *C.PJ ) {
+    if owner == nil || (*owner).pj == nil {
+        orphansMu.Lock()
+        C.proj_assign_context(pj, orphans)
+        C.proj_destroy(pj)
+        orphansMu.Unlock()
+    } else {
+        C.proj_destroy(pj)
+    }
+    released()
+}
+
+// init package initialisation
+//
+func init () {
+    orphans = C.proj_context_create()
+}
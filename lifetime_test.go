@@ -0,0 +1,98 @@
+package proj
+
+import (
+    "testing"
+    "io"
+    "runtime"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Tests :
+
+// waitLiveObjects runs the garbage collector until the number of live
+// objects reaches `n` or a second has elapsed.
+func waitLiveObjects ( n int ) int {
+    for i := 0 ; i < 100 && LiveObjects() != n ; i++ {
+        runtime.GC()
+        time.Sleep(10*time.Millisecond)
+    }
+    return LiveObjects()
+}
+
+// TestClose checks io.Closer implementations release PROJ objects.
+func TestClose ( t *testing.T ) {
+    n := LiveObjects()
+    c := NewContext()
+    crs, e := NewReferenceSystem(c, "EPSG:4326")
+    if e != nil {
+        t.Fatal(e)
+    }
+    a := NewArea(-180,-90,180,90)
+    if LiveObjects() != n+3 {
+        t.Errorf("Expected %d live objects, but got %d", n+3, LiveObjects())
+    }
+    for _, o := range []io.Closer{crs, a, c} {
+        if e = o.Close() ; e != nil {
+            t.Error(e)
+        }
+    }
+    if LiveObjects() != n {
+        t.Errorf("Expected %d live objects, but got %d", n, LiveObjects())
+    }
+    // closing twice is harmless :
+    crs.Close()
+    if LiveObjects() != n {
+        t.Errorf("Expected %d live objects, but got %d", n, LiveObjects())
+    }
+}
+
+// TestFinalizers checks leaked PROJ objects are released and logged.
+func TestFinalizers ( t *testing.T ) {
+    buf, restore := captureLog()
+    defer restore()
+    SetLeakLogging(true)
+    defer SetLeakLogging(false)
+    n := waitLiveObjects(LiveObjects())
+    func () {
+        c := NewContext()
+        if _, e := NewReferenceSystem(c, "EPSG:4326") ; e != nil {
+            t.Error(e)
+        }
+    }()
+    if m := waitLiveObjects(n) ; m != n {
+        t.Errorf("Expected %d live objects, but got %d", n, m)
+    }
+    if !strings.Contains(buf.String(), "was not closed") {
+        t.Errorf("Expected leaks to be logged, got '%s'", buf.String())
+    }
+}
+
+// TestOrphans checks objects outliving their context are destroyed from
+// several goroutines.
+func TestOrphans ( t *testing.T ) {
+    n := LiveObjects()
+    crss := make([]*ReferenceSystem, 8)
+    for i := range crss {
+        c := NewContext()
+        crs, e := NewReferenceSystem(c, "EPSG:4326")
+        if e != nil {
+            t.Fatal(e)
+        }
+        crss[i] = crs
+        c.DestroyContext()
+    }
+    var wg sync.WaitGroup
+    for _, crs := range crss {
+        wg.Add(1)
+        go func ( crs *ReferenceSystem ) {
+            defer wg.Done()
+            crs.DestroyReferenceSystem()
+        }(crs)
+    }
+    wg.Wait()
+    if LiveObjects() != n {
+        t.Errorf("Expected %d live objects, but got %d", n, LiveObjects())
+    }
+}
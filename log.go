@@ -8,9 +8,13 @@ package proj
 import "C"
 
 import (
+    "context"
     "os"
+    "runtime"
     "log"
+    "log/slog"
     "fmt"
+    "time"
 )
 
 // LoggingLevel lists logging levels in PROJ. Used to set the logging level in PROJ.
@@ -27,6 +31,29 @@ const (
     Trace  LoggingLevel = C.PJ_LOG_TRACE
 )
 
+// String returns the name of the level, e.g. "debug".
+//
+func (lvl LoggingLevel) String () string {
+    switch lvl {
+    case None :
+        return "none"
+    case Error :
+        return "error"
+    case Debug :
+        return "debug"
+    case Trace :
+        return "trace"
+    default :
+        return fmt.Sprintf("level(%d)", int(lvl))
+    }
+}
+
+// LogHandler receives the messages PROJ emits through a context, tagged
+// with their level. PROJ only emits the messages at or below the log level
+// of the context (see `SetLogLevel`).
+//
+type LogHandler func ( ctx *Context, lvl LoggingLevel, msg string )
+
 var (
   qlog *log.Logger
   // LoggerPrefix for logged messages
@@ -37,11 +64,14 @@ var (
 
 // LogOnCError wraps message from PROJ to this logger
 //
+// Deprecated: PROJ messages are now routed per context to the `LogHandler`
+// given to `(*Context).SetLogger`. It is kept for compatibility and hands
+// `msg` to `DefaultLogHandler` as an error.
+//
 //export LogOnCError
 func LogOnCError ( msg *C.char ) {
     if msg != nil {
-        LogOnError(fmt.Errorf(C.GoString(msg)))
-        return
+        DefaultLogHandler(nil, Error, C.GoString(msg))
     }
 }
 
@@ -60,22 +90,152 @@ func Log () *log.Logger {
     return qlog
 }
 
-// SetLog overrides the C logging function with `logFuncToGo`
+// SetLog overrides the C logging function with `logFuncToGo`. Contexts
+// created by `NewContext` already route their messages to Go.
 //
 func SetLog ( ctx *Context ) {
-    C.proj_log_func((*ctx).pj, nil, C.PJ_LOG_FUNCTION(C.logFuncToGo));
+    defer runtime.KeepAlive(ctx)
+    C.setLogFunc((*ctx).pj, C.uintptr_t((*ctx).id))
+}
+
+// DefaultLogHandler writes the messages to the package logger (see `Log()`),
+// debug and trace messages being prefixed by their level.
+//
+func DefaultLogHandler ( ctx *Context, lvl LoggingLevel, msg string ) {
+    if lvl == Error {
+        qlog.Print(msg)
+        return
+    }
+    qlog.Printf("[%s] %s", lvl, msg)
+}
+
+// SlogLogHandler returns a LogHandler writing the messages to a log/slog
+// handler. PROJ levels are mapped to slog.LevelError, slog.LevelDebug and
+// slog.LevelDebug-4 (trace); each record holds the context as attribute :
+//
+//   ctx.SetLogger(SlogLogHandler(slog.NewJSONHandler(os.Stderr, nil)))
+//
+func SlogLogHandler ( h slog.Handler ) LogHandler {
+    return func ( ctx *Context, lvl LoggingLevel, msg string ) {
+        var slvl slog.Level
+        switch lvl {
+        case Error :
+            slvl = slog.LevelError
+        case Debug :
+            slvl = slog.LevelDebug
+        default :
+            slvl = slog.LevelDebug - 4
+        }
+        bg := context.Background()
+        if !h.Enabled(bg, slvl) {
+            return
+        }
+        r := slog.NewRecord(time.Now(), slvl, msg, 0)
+        r.AddAttrs(slog.String("source", "proj"), slog.String("context", fmt.Sprintf("%p", ctx)))
+        _ = h.Handle(bg, r)
+    }
+}
+
+// Logger returns the handler receiving the messages of the context,
+// `DefaultLogHandler` when none has been assigned.
+//
+func (ctx *Context) Logger () LogHandler {
+    if (*ctx).logger == nil {
+        return DefaultLogHandler
+    }
+    return (*ctx).logger
+}
+
+// SetLogger assigns the handler receiving every message PROJ emits through
+// the context, whatever its level. A nil handler reverts to
+// `DefaultLogHandler`.
+//
+//   ctx.SetLogger(func ( ctx *proj.Context, lvl proj.LoggingLevel, msg string ) {
+//       logger.Printf("%s: %s", lvl, msg)
+//   })
+//   ctx.SetLogLevel(proj.Debug)
+//
+func (ctx *Context) SetLogger ( h LogHandler ) {
+    (*ctx).logger = h
+    (*ctx).settings++
+    SetLog(ctx)
+}
+
+// logOnC is called by PROJ (through `logFuncToGo`) for each message emitted
+// through a context.
+//
+//export logOnC
+func logOnC ( id C.uintptr_t, lvl C.int, msg *C.char ) {
+    if msg == nil {
+        return
+    }
+    ctx := lookup(uintptr(id))
+    if ctx == nil {
+        DefaultLogHandler(nil, LoggingLevel(lvl), C.GoString(msg))
+        return
+    }
+    if (*ctx).capturing > 0 {
+        if LoggingLevel(lvl) == Error {
+            (*ctx).captured = append((*ctx).captured, C.GoString(msg))
+        }
+        if LoggingLevel(lvl) > (*ctx).userLevel { // not asked for by the user
+            return
+        }
+    }
+    ctx.Logger()(ctx, LoggingLevel(lvl), C.GoString(msg))
+}
+
+// capture buffers the error messages PROJ logs through the context until
+// the returned function is called; it then attaches them to the error `*e`
+// when it is a *ProjError. The log level is raised to Error meanwhile, the
+// handler of the context only receiving the messages it asked for :
+//
+//   func NewX ( ctx *Context, ... ) ( x *X, e error ) {
+//       defer ctx.capture()(&e)
+//
+func (ctx *Context) capture () func ( e *error ) {
+    if ctx == nil || (*ctx).pj == nil {
+        return func ( e *error ) {}
+    }
+    if (*ctx).capturing == 0 {
+        (*ctx).captured = nil
+        (*ctx).userLevel = LoggingLevel(C.proj_log_level((*ctx).pj, C.PJ_LOG_TELL))
+        if (*ctx).userLevel < Error {
+            _ = C.proj_log_level((*ctx).pj, C.PJ_LOG_ERROR)
+        }
+    }
+    (*ctx).capturing++
+    return func ( e *error ) {
+        (*ctx).capturing--
+        msgs := (*ctx).captured
+        if (*ctx).capturing == 0 {
+            if (*ctx).pj != nil && (*ctx).userLevel < Error {
+                _ = C.proj_log_level((*ctx).pj, (C.PJ_LOG_LEVEL)((*ctx).userLevel))
+            }
+            (*ctx).captured = nil
+        }
+        if *e == nil || len(msgs) == 0 {
+            return
+        }
+        if pe, ok := (*e).(*ProjError) ; ok && pe.Messages == nil {
+            pe.Messages = append([]string(nil), msgs...)
+        }
+    }
 }
 
 // LogLevel returns the current log level of PROJ.
 //
 func LogLevel ( ctx *Context ) LoggingLevel {
+    defer runtime.KeepAlive(ctx)
     return (LoggingLevel)(C.proj_log_level( (*ctx).pj, C.PJ_LOG_TELL) )
 }
 
 // SetLogLevel assigns the log level of PROJ.
 //
 func SetLogLevel ( ctx *Context, lvl LoggingLevel ) {
+    defer runtime.KeepAlive(ctx)
     _ = C.proj_log_level( (*ctx).pj, (C.PJ_LOG_LEVEL)(lvl) )
+    (*ctx).settings++
 }
 
 // init package initialisation : logger writes to os.Stderr using LoggerPrefix
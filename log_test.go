@@ -5,6 +5,7 @@ import (
     "bytes"
     "strings"
     "fmt"
+    "log/slog"
 )
 
 // Tests :
@@ -56,3 +57,63 @@ func TestLogging ( t *testing.T) {
     SetLogLevel(ctx,None)
 }
 
+
+// TestContextLogger checks messages of all levels reach the handler of
+// their context.
+func TestContextLogger ( t *testing.T ) {
+    c := NewContext()
+    defer c.DestroyContext()
+    type message struct {
+        ctx *Context
+        lvl LoggingLevel
+        msg string
+    }
+    var msgs []message
+    c.SetLogger(func ( ctx *Context, lvl LoggingLevel, msg string ) {
+        msgs = append(msgs, message{ctx, lvl, msg})
+    })
+    c.SetLogLevel(Trace)
+    if _, e := NewOperation(c, nil, "+proj=UnKnownProjection") ; e == nil {
+        t.Fatalf("Expected an error for an unknown projection")
+    }
+    if len(msgs) == 0 {
+        t.Fatalf("Expected messages from PROJ")
+    }
+    errors := 0
+    for _, m := range msgs {
+        if m.ctx != c {
+            t.Errorf("Expected message from context %p, but got %p", c, m.ctx)
+        }
+        if m.lvl == Error {
+            errors++
+        }
+    }
+    if errors == 0 {
+        t.Errorf("Expected at least one error message in %v", msgs)
+    }
+    c.SetLogger(nil)
+    buf, restore := captureLog()
+    defer restore()
+    NewOperation(c, nil, "+proj=UnKnownProjection")
+    if buf.Len() == 0 {
+        t.Errorf("Expected messages to reach the default handler")
+    }
+}
+
+// TestSlogLogHandler checks messages are written to a slog handler.
+func TestSlogLogHandler ( t *testing.T ) {
+    var buf bytes.Buffer
+    h := SlogLogHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level:slog.LevelError}))
+    h(ctx, Debug, "debug message")
+    if buf.Len() != 0 {
+        t.Errorf("Unexpected debug message %s", buf.String())
+    }
+    h(ctx, Error, "error message")
+    s := buf.String()
+    if !strings.Contains(s, "level=ERROR") || !strings.Contains(s, "error message") || !strings.Contains(s, "source=proj") {
+        t.Errorf("Unexpected record %s", s)
+    }
+    if Trace.String() != "trace" {
+        t.Errorf("Expected 'trace', but got '%s'", Trace)
+    }
+}
@@ -0,0 +1,133 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "runtime"
+    "sort"
+    "strings"
+    "unsafe"
+)
+
+// DatabaseMetadata holds the metadata of the database (proj.db) of a
+// context. Fields are empty when the database does not hold the matching
+// key.
+//
+type DatabaseMetadata struct {
+    // LayoutVersion is the version of the database structure, e.g. "1.0"
+    LayoutVersion   string
+    // EPSGVersion is the version of the EPSG dataset, e.g. "v9.8.6"
+    EPSGVersion     string
+    // EPSGDate is the release date of the EPSG dataset, e.g. "2020-01-21"
+    EPSGDate        string
+    // ESRIVersion is the version of the ESRI dataset
+    ESRIVersion     string
+    // ESRIDate is the release date of the ESRI dataset
+    ESRIDate        string
+    // IGNFVersion is the version of the IGNF dataset
+    IGNFVersion     string
+    // IGNFDate is the release date of the IGNF dataset
+    IGNFDate        string
+    // PROJVersion is the PROJ release the database was built for
+    PROJVersion     string
+    // PROJDataVersion is the version of the PROJ-data grid package the
+    // database refers to
+    PROJDataVersion string
+    // Raw holds every key of the metadata table of the database with its
+    // value, those of the fields above included
+    Raw             map[string]string
+}
+
+// DatabaseMetadataValue returns the value of a key of the metadata table of
+// the database, e.g. "EPSG.VERSION", and false when the key is missing.
+//
+func (ctx *Context) DatabaseMetadataValue ( key string ) ( string, bool ) {
+    defer runtime.KeepAlive(ctx)
+    k := C.CString(key)
+    defer C.free(unsafe.Pointer(k))
+    v := C.proj_context_get_database_metadata((*ctx).pj, k)
+    if v == nil {
+        return "", false
+    }
+    return C.GoString(v), true
+}
+
+// DatabaseMetadata returns the metadata of the database, e.g. to record
+// the EPSG dataset version used by a transformation.
+//
+func (ctx *Context) DatabaseMetadata () ( *DatabaseMetadata, error ) {
+    db := ctx.DatabasePath()
+    if db == "" {
+        return nil, newError(ctx, "DatabaseMetadata", "", nil, "No database")
+    }
+    raw, e := databaseMetadata(ctx, db)
+    if e != nil {
+        var pe *ProjError
+        if errors.As(e, &pe) {
+            pe.Op = "DatabaseMetadata"
+        }
+        return nil, e
+    }
+    md := &DatabaseMetadata{Raw:raw}
+    if major, ok := md.Raw["DATABASE.LAYOUT.VERSION.MAJOR"] ; ok {
+        md.LayoutVersion = major + "." + md.Raw["DATABASE.LAYOUT.VERSION.MINOR"]
+    }
+    md.EPSGVersion = md.Raw["EPSG.VERSION"]
+    md.EPSGDate = md.Raw["EPSG.DATE"]
+    md.ESRIVersion = md.Raw["ESRI.VERSION"]
+    md.ESRIDate = md.Raw["ESRI.DATE"]
+    md.IGNFVersion = md.Raw["IGNF.VERSION"]
+    md.IGNFDate = md.Raw["IGNF.DATE"]
+    md.PROJVersion = md.Raw["PROJ.VERSION"]
+    md.PROJDataVersion = md.Raw["PROJ_DATA.VERSION"]
+    return md, nil
+}
+
+// Diagnostics returns a human readable report of the PROJ setup of the
+// context : release, database, metadata, search paths and authorities.
+//
+//   PROJ release    : Rel. 6.3.1, February 10th, 2020
+//   PROJ version    : 6.3.1
+//   Database        : /usr/local/share/proj/proj.db
+//   EPSG.DATE       : 2020-01-21
+//   ...
+//
+func (ctx *Context) Diagnostics () string {
+    var b strings.Builder
+    line := func ( k, v string ) {
+        fmt.Fprintf(&b, "%-16s: %s\n", k, v)
+    }
+    line("PROJ release", Release())
+    line("PROJ version", VersionNumber())
+    db := ctx.DatabasePath()
+    if db == "" {
+        db = "none"
+    }
+    line("Database", db)
+    if md, e := ctx.DatabaseMetadata() ; e == nil {
+        keys := make([]string, 0, len(md.Raw))
+        for k := range md.Raw {
+            keys = append(keys, k)
+        }
+        sort.Strings(keys)
+        for _, k := range keys {
+            line(k, md.Raw[k])
+        }
+    }
+    paths := ctx.SearchPaths()
+    if paths == nil {
+        line("Search paths", "default")
+    } else {
+        line("Search paths", strings.Join(paths, string(os.PathListSeparator)))
+    }
+    line("Authorities", strings.Join(ctx.Authorities(), ", "))
+    return b.String()
+}
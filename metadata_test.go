@@ -0,0 +1,43 @@
+package proj
+
+import (
+    "strings"
+    "testing"
+)
+
+// Tests :
+
+// TestDatabaseMetadata checks the metadata of the default database.
+func TestDatabaseMetadata ( t *testing.T ) {
+    md, e := ctx.DatabaseMetadata()
+    if e != nil {
+        t.Fatal(e)
+    }
+    if md.EPSGVersion == "" || md.EPSGDate == "" {
+        t.Errorf("Expected EPSG version and date, but got '%s' and '%s'", md.EPSGVersion, md.EPSGDate)
+    }
+    if md.EPSGVersion != md.Raw["EPSG.VERSION"] {
+        t.Errorf("Expected raw EPSG.VERSION to be '%s', but got '%s'", md.EPSGVersion, md.Raw["EPSG.VERSION"])
+    }
+    if md.LayoutVersion == "" {
+        t.Errorf("Expected a database layout version")
+    }
+    for k, v := range md.Raw {
+        if w, ok := ctx.DatabaseMetadataValue(k) ; !ok || w != v {
+            t.Errorf("Expected '%s' for %s, but got '%s'", v, k, w)
+        }
+    }
+    if _, ok := ctx.DatabaseMetadataValue("NO.SUCH.KEY") ; ok {
+        t.Errorf("Unexpected value for an unknown key")
+    }
+}
+
+// TestDiagnostics checks the report holds the main settings.
+func TestDiagnostics ( t *testing.T ) {
+    d := ctx.Diagnostics()
+    for _, s := range []string{VersionNumber(), ctx.DatabasePath(), "EPSG.VERSION", "EPSG"} {
+        if !strings.Contains(d, s) {
+            t.Errorf("Expected '%s' in diagnostics:\n%s", s, d)
+        }
+    }
+}
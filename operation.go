@@ -8,7 +8,9 @@ package proj
 import "C"
 
 import (
+    "runtime"
     "unsafe"
+    "strings"
     "fmt"
 )
 
@@ -16,7 +18,9 @@ import (
 // coordinate transformation.
 //
 type Operation struct {
-    pj *C.PJ
+    pj  *C.PJ
+    ctx *Context    // owning context
+    use usage       // concurrent use tracker
 }
 
 // NewOperation creates a reference system object from a proj-string, a WKT string,
@@ -43,11 +47,12 @@ type Operation struct {
 //   ope, e := NewOperation(ctx, bbox, "EPSG:25832", "EPSG:25833")
 //
 func NewOperation ( ctx *Context, bbox *Area, def ...string ) (op *Operation, e error) {
+    defer ctx.capture()(&e)
     var pj *C.PJ
     l := len(def)
     switch {
     case l==0 :
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(-1)))
+        e = errnoError(ctx, "NewOperation", "", -1, ErrInvalidDefinition)
         return
     case l==1 :
         pj, e = NewPJ(ctx, def[0], "Operation", C.PJ_CATEGORY_COORDINATE_OPERATION)
@@ -63,18 +68,18 @@ func NewOperation ( ctx *Context, bbox *Area, def ...string ) (op *Operation, e
         defer tgt.DestroyReferenceSystem()
         opeFactory := C.proj_create_operation_factory_context((*ctx).pj, nil)
         if opeFactory == (*C.PJ_OPERATION_FACTORY_CONTEXT)(nil) {
-            e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+            e = contextError(ctx, "NewOperation", strings.Join(def, " "), nil)
             return
         }
         defer C.proj_operation_factory_context_destroy(opeFactory)
         candidateOps := C.proj_create_operations((*ctx).pj, (*src).pj, (*tgt).pj, opeFactory)
         if candidateOps == (*C.PJ_OBJ_LIST)(nil) {
-            e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+            e = contextError(ctx, "NewOperation", strings.Join(def, " "), ErrNotACRS)
             return
         }
         defer C.proj_list_destroy(candidateOps)
         if C.proj_list_get_count(candidateOps) == 0 {
-            e = fmt.Errorf("No operation found between '%s' and '%s'", def[0], def[1])
+            e = newError(ctx, "NewOperation", strings.Join(def, " "), ErrNoOperation, fmt.Sprintf("No operation found between '%s' and '%s'", def[0], def[1]))
             return
         }
         pj = C.proj_list_get((*ctx).pj, candidateOps, C.int(0))
@@ -91,10 +96,10 @@ func NewOperation ( ctx *Context, bbox *Area, def ...string ) (op *Operation, e
         C.destroyStringArray(&defs)
     }
     if pj == (*C.PJ)(nil) {
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+        e = contextError(ctx, "NewOperation", strings.Join(def, " "), ErrInvalidDefinition)
         return
     }
-    op = &Operation{pj:pj}
+    op = newOperation(ctx, pj)
     switch op.TypeOf() {
     case Conversion,
          Transformation,
@@ -102,22 +107,42 @@ func NewOperation ( ctx *Context, bbox *Area, def ...string ) (op *Operation, e
          OtherCoordinateOperation :
         return
     default :
-        e = fmt.Errorf("%v does not yield an Operation", def)
+        e = newError(ctx, "NewOperation", strings.Join(def, " "), ErrNotAnOperation, "does not yield an Operation")
         op.DestroyOperation()
         op = nil
     }
     return
 }
 
+// newOperation wraps the PROJ pointer created in the given context.
+//
+func newOperation ( ctx *Context, pj *C.PJ ) *Operation {
+    op := &Operation{pj:pj, ctx:ctx}
+    allocated()
+    runtime.SetFinalizer(op, func ( op *Operation ) {
+        leaked(op)
+        destroyPJ(nil, (*op).pj)
+    })
+    return op
+}
+
 // DestroyOperation deallocates the internal Operation object.
 //
 func (op *Operation) DestroyOperation () {
     if (*op).pj != nil {
-        C.proj_destroy((*op).pj)
+        destroyPJ((*op).ctx, (*op).pj)
         (*op).pj = nil
+        runtime.SetFinalizer(op, nil)
     }
 }
 
+// Close deallocates the internal Operation object. It implements io.Closer.
+//
+func (op *Operation) Close () error {
+    op.DestroyOperation()
+    return nil
+}
+
 // Handle returns the PROJ internal object to be passed to the PROJ library.
 // Cannot be tested against nil as it returns a pointer to a type, so use :
 //   if p.HandleIsNil() { ... }
@@ -132,6 +157,46 @@ func (op *Operation) HandleIsNil () bool {
     return (*op).pj == (*C.PJ)(nil)
 }
 
+// Context returns the context owning the operation.
+//
+func (op *Operation) Context () *Context {
+    return (*op).ctx
+}
+
+// guard returns the concurrent use tracker of the operation.
+//
+func (op *Operation) guard () *usage {
+    return &((*op).use)
+}
+
+// Clone duplicates the operation into the given context. The owning context
+// is used when `ctx` is nil.
+//
+func (op *Operation) Clone ( ctx *Context ) ( c *Operation, e error ) {
+    if ctx == nil {
+        ctx = (*op).ctx
+    }
+    defer op.use.enter(op, nil, nil)()
+    defer runtime.KeepAlive(op)
+    var pj *C.PJ
+    if pj, e = clonePJ(ctx, (*op).pj, "Operation") ; e != nil {
+        return
+    }
+    c = newOperation(ctx, pj)
+    return
+}
+
+// AssignContext moves the operation to the given context, which then owns
+// it. Must not be called while the operation is in use. It panics when `ctx`
+// is nil.
+//
+func (op *Operation) AssignContext ( ctx *Context ) {
+    defer op.use.enter(op, nil, nil)()
+    defer runtime.KeepAlive(op)
+    C.proj_assign_context((*op).pj, (*ctx).pj)
+    (*op).ctx = ctx
+}
+
 // TypeOf returns the ISOType of an operation (Conversion, Transformation,
 // ConcatenatedOperation, OtherCoordinateOperation).
 // UnKnownType on error.
@@ -140,14 +205,35 @@ func (op *Operation) TypeOf ( ) ISOType {
     return hasType(op)
 }
 
+// AngularInput returns true when the operation expects angular input
+// coordinates, in radians, in the given direction.
+//
+func (op *Operation) AngularInput ( d Direction ) bool {
+    defer runtime.KeepAlive(op)
+    return C.proj_angular_input((*op).pj, C.enum_PJ_DIRECTION(d)) == C.int(1)
+}
+
+// AngularOutput returns true when the operation returns angular output
+// coordinates, in radians, in the given direction.
+//
+func (op *Operation) AngularOutput ( d Direction ) bool {
+    defer runtime.KeepAlive(op)
+    return C.proj_angular_output((*op).pj, C.enum_PJ_DIRECTION(d)) == C.int(1)
+}
+
+// fwdinv does not capture the messages of PROJ : it runs once per
+// coordinate and errno already tells the failure.
+//
 func (op *Operation) fwdinv ( d Direction, aC *Coordinate ) ( aR *Coordinate, e error ) {
+    defer op.use.enter(op, nil, nil)()
+    defer runtime.KeepAlive(op)
     var cpj, cc C.PJ_COORD
     _ = C.proj_errno_reset((*op).pj)
     // make a copy not to change coord in case of error :
     _ = C.memcpy(unsafe.Pointer(&cc), unsafe.Pointer(&((*aC).pj)), C.sizeof_PJ_COORD)
     cpj = C.proj_trans((*op).pj, C.PJ_DIRECTION(d), cc)
     if En := C.proj_errno((*op).pj) ; En != C.int(0) {
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(En)))
+        e = errnoError((*op).ctx, "Transform", op.String(), En, nil)
     } else {
         // everything's ok, copy back :
         _ = C.memcpy(unsafe.Pointer(&((*aC).pj)), unsafe.Pointer(&cpj), C.sizeof_PJ_COORD)
@@ -157,13 +243,20 @@ func (op *Operation) fwdinv ( d Direction, aC *Coordinate ) ( aR *Coordinate, e
 }
 
 func (op *Operation) fwdinv_array(d Direction, aCArray []Coordinate) (aR []Coordinate, e error) {
+    defer op.use.enter(op, nil, nil)()
+    defer runtime.KeepAlive(op)
+    defer (*op).ctx.capture()(&e)
+    if len(aCArray) == 0 {
+        aR = aCArray
+        return
+    }
     ccArray := make([]C.PJ_COORD, len(aCArray))
     _ = C.proj_errno_reset((*op).pj)
     // make a copy not to change coord in case of error :
     _ = C.memcpy(unsafe.Pointer(&ccArray[0]), unsafe.Pointer(&(aCArray[0])), C.sizeof_PJ_COORD*C.size_t(len(aCArray)))
     En := C.proj_trans_array((*op).pj, C.PJ_DIRECTION(d), C.size_t(len(ccArray)), (*C.PJ_COORD)(&ccArray[0]))
     if En != C.int(0) {
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(En)))
+        e = errnoError((*op).ctx, "TransformArray", op.String(), En, nil)
     } else {
         // everything's ok, copy back :
         _ = C.memcpy(unsafe.Pointer(&(aCArray[0])), unsafe.Pointer(&ccArray[0]), C.sizeof_PJ_COORD*C.size_t(len(aCArray)))
@@ -193,6 +286,23 @@ func (op *Operation) TransformArray(d Direction, c []Coordinate) (r []Coordinate
     return
 }
 
+// TransformBatch applies the transformation of coordinates to a collection
+// implementing `LocatableBatch` either from or to the CRS. All coordinates
+// are transformed with a single call to PROJ : on error, none of the items
+// is changed.
+// Returns the collection with transformed coordinates or nil on error.
+//
+func (op *Operation) TransformBatch ( d Direction, b LocatableBatch ) ( r LocatableBatch, e error ) {
+    buf := make([]Coordinate, b.Len())
+    b.Coordinates(buf)
+    if _, e = op.fwdinv_array(d, buf) ; e != nil {
+        return
+    }
+    b.SetCoordinates(buf)
+    r = b
+    return
+}
+
 // Factors creates various cartographic properties, such as scale factors,
 // angular distortion and meridian convergence.
 // Depending on the underlying projection values will be calculated either
@@ -202,11 +312,13 @@ func (op *Operation) TransformArray(d Direction, c []Coordinate) (r []Coordinate
 // geographical coordinate.
 //
 func (op *Operation) Factors ( c *Coordinate) ( f *Factors, e error ) {
+    defer op.use.enter(op, nil, nil)()
+    defer runtime.KeepAlive(op)
     _ = C.proj_errno_reset((*op).pj)
     var pjf C.PJ_FACTORS
     pjf = C.proj_factors((*op).pj, (*c).pj)
     if En := C.proj_errno((*op).pj) ; En != C.int(0) {
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(En)))
+        e = errnoError((*op).ctx, "Factors", op.String(), En, nil)
     } else {
         f = &Factors{pj:pjf}
     }
@@ -216,6 +328,7 @@ func (op *Operation) Factors ( c *Coordinate) ( f *Factors, e error ) {
 // Info returns information about a specific operation object.
 //
 func (op *Operation) Info ( ) ( *ISOInfo ) {
+    defer runtime.KeepAlive(op)
     return &ISOInfo{pj:C.proj_pj_info((*op).pj)}
 }
 
@@ -210,3 +210,37 @@ func TestOperation_othercoordinateoperation ( t *testing.T ) {
     o.DestroyOperation()
 }
 
+
+// coordinates implements LocatableBatch for tests
+type coordinates []Coordinate
+func (cs coordinates) Len () int { return len(cs) }
+func (cs coordinates) Coordinates ( buf []Coordinate ) { copy(buf, cs) }
+func (cs coordinates) SetCoordinates ( buf []Coordinate ) { copy(cs, buf) }
+
+func TestOperation_batch ( t *testing.T ) {
+    o, e := NewOperation(ctx, &Area{}, "EPSG:4326", "EPSG:32631")
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer o.DestroyOperation()
+    if _, e = o.TransformBatch(Forward, coordinates{}) ; e != nil {
+        t.Errorf("Unexpected failure on an empty batch : %v", e)
+    }
+    cs := coordinates{*NewCoordinate(0.0,3.0), *NewCoordinate(0.0,3.0)}
+    r, e := o.TransformBatch(Forward, cs)
+    if e != nil {
+        t.Fatal(e)
+    }
+    for i := range r.(coordinates) {
+        if math.Abs(cs[i].X() - 500000.0) > 1e-9 || math.Abs(cs[i].Y()) > 1e-9 {
+            t.Errorf("Expected (500000.0, 0.0), but got (%.1f, %.1f)", cs[i].X(), cs[i].Y())
+        }
+    }
+    cs = coordinates{*NewCoordinate(0.0,3.0), *NewCoordinate(500000.0,0.0)}
+    if _, e = o.TransformBatch(Forward, cs) ; e == nil {
+        t.Errorf("Expected TransformBatch to fail (wrong direction)")
+    }
+    if cs[0].X() != 0.0 || cs[0].Y() != 3.0 {
+        t.Errorf("Expected the batch to be left unchanged on error")
+    }
+}
@@ -0,0 +1,228 @@
+package proj
+
+import (
+    "errors"
+    "sync"
+    "time"
+)
+
+// ErrPoolClosed is returned when getting a context from a closed pool.
+//
+var ErrPoolClosed = errors.New("context pool closed")
+
+// ErrForeignContext is returned when putting back a context the pool did
+// not create.
+//
+var ErrForeignContext = errors.New("context not created by the pool")
+
+// PoolOptions holds the configuration shared by the contexts of a pool and
+// the limits of the pool. Settings of the contexts belong to the embedded
+// `ContextOptions`, the other fields to the pool only.
+//
+type PoolOptions struct {
+    // ContextOptions are the settings of every context
+    ContextOptions
+    // MaxContexts caps the number of live contexts, 0 for no limit. `Get`
+    // waits for a context to be put back when the cap is reached.
+    MaxContexts     int
+    // IdleTimeout is the duration after which an unused context is closed,
+    // 0 to keep them until the pool is closed.
+    IdleTimeout     time.Duration
+}
+
+// ContextPool hands out contexts sharing the same configuration to
+// concurrent goroutines, e.g. the handlers of an HTTP service. Contexts are
+// created lazily and reused :
+//
+//   pool := NewContextPool(PoolOptions{MaxContexts:runtime.NumCPU(), IdleTimeout:time.Minute})
+//   defer pool.Close()
+//   ...
+//   e := pool.With(func ( ctx *Context ) error {
+//       op, e := NewOperation(ctx, nil, "EPSG:4326", "EPSG:2154")
+//       ...
+//   })
+//
+// The objects created through a context must be destroyed before the
+// context is put back. A context whose settings were changed (search
+// paths, database, logger, registered CRS, ...) is closed when put back, so
+// that `Get` only returns contexts matching the options of the pool.
+//
+type ContextPool struct {
+    opts    PoolOptions
+    mu      sync.Mutex
+    cond    *sync.Cond
+    idle    []idleContext           // contexts put back, most recent last
+    live    int                     // number of contexts created and not closed
+    owned   map[*Context]uint64     // live contexts with their count of settings changes
+    closed  bool
+    done    chan struct{}           // closed when the pool is closed
+}
+
+// idleContext is a context waiting in the pool.
+//
+type idleContext struct {
+    ctx     *Context
+    since   time.Time
+}
+
+// NewContextPool creates an empty pool of contexts.
+//
+func NewContextPool ( opts PoolOptions ) *ContextPool {
+    opts.ContextOptions = opts.ContextOptions.clone()
+    p := &ContextPool{opts:opts, owned:make(map[*Context]uint64), done:make(chan struct{})}
+    p.cond = sync.NewCond(&p.mu)
+    if opts.IdleTimeout > 0 {
+        go p.reaper()
+    }
+    return p
+}
+
+// Get returns an idle context, or a new one when none is idle. It waits
+// when `MaxContexts` contexts are already in use. It fails when the options
+// cannot be applied to a new context. The context must be given back with
+// `Put`.
+//
+func (p *ContextPool) Get () ( *Context, error ) {
+    p.mu.Lock()
+    for {
+        if p.closed {
+            p.mu.Unlock()
+            return nil, ErrPoolClosed
+        }
+        if n := len(p.idle) ; n > 0 {
+            ctx := p.idle[n-1].ctx
+            p.idle = p.idle[:n-1]
+            p.mu.Unlock()
+            return ctx, nil
+        }
+        if p.opts.MaxContexts <= 0 || p.live < p.opts.MaxContexts {
+            p.live++
+            p.mu.Unlock()
+            ctx, e := p.newContext()
+            p.mu.Lock()
+            if e != nil {
+                p.live--
+                p.cond.Signal()
+            } else {
+                p.owned[ctx] = (*ctx).settings
+            }
+            p.mu.Unlock()
+            return ctx, e
+        }
+        p.cond.Wait()
+    }
+}
+
+// Put gives a context back to the pool. The context is closed when the
+// pool is closed or when its settings were changed since `Get`. It fails
+// with ErrForeignContext for a context the pool did not create.
+//
+func (p *ContextPool) Put ( ctx *Context ) error {
+    if ctx == nil {
+        return nil
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    settings, ok := p.owned[ctx]
+    if !ok {
+        return ErrForeignContext
+    }
+    if p.closed || ctx.HandleIsNil() || (*ctx).settings != settings {
+        p.release(ctx)
+        p.cond.Signal()
+        return nil
+    }
+    p.idle = append(p.idle, idleContext{ctx:ctx, since:time.Now()})
+    p.cond.Signal()
+    return nil
+}
+
+// release closes a context of the pool, the lock being held.
+//
+func (p *ContextPool) release ( ctx *Context ) {
+    delete(p.owned, ctx)
+    p.live--
+    ctx.DestroyContext()
+}
+
+// With calls `f` with a context of the pool, and puts the context back
+// whatever `f` returns.
+//
+func (p *ContextPool) With ( f func ( ctx *Context ) error ) error {
+    ctx, e := p.Get()
+    if e != nil {
+        return e
+    }
+    defer p.Put(ctx)
+    return f(ctx)
+}
+
+// Len returns the number of live contexts and the number of idle ones.
+//
+func (p *ContextPool) Len () ( live int, idle int ) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.live, len(p.idle)
+}
+
+// Close closes the idle contexts, and the others as soon as they are put
+// back. Waiting and later calls to `Get` fail with ErrPoolClosed. It
+// implements io.Closer.
+//
+func (p *ContextPool) Close () error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return nil
+    }
+    p.closed = true
+    close(p.done)
+    for _, ic := range p.idle {
+        p.release(ic.ctx)
+    }
+    p.idle = nil
+    p.cond.Broadcast()
+    return nil
+}
+
+// newContext creates a context configured with the options of the pool.
+//
+func (p *ContextPool) newContext () ( *Context, error ) {
+    return NewContextWithOptions(p.opts.ContextOptions)
+}
+
+// reaper periodically closes the contexts idle for longer than
+// `IdleTimeout`, until the pool is closed.
+//
+func (p *ContextPool) reaper () {
+    t := time.NewTicker(p.opts.IdleTimeout / 2 + time.Millisecond)
+    defer t.Stop()
+    for {
+        select {
+        case <-p.done :
+            return
+        case now := <-t.C :
+            p.closeIdle(now)
+        }
+    }
+}
+
+// closeIdle closes the contexts idle since before `now - IdleTimeout`.
+//
+func (p *ContextPool) closeIdle ( now time.Time ) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    limit := now.Add(-p.opts.IdleTimeout)
+    kept := p.idle[:0]
+    for _, ic := range p.idle {
+        if ic.since.Before(limit) {
+            p.release(ic.ctx)
+            continue
+        }
+        kept = append(kept, ic)
+    }
+    for i := len(kept) ; i < len(p.idle) ; i++ {
+        p.idle[i] = idleContext{}
+    }
+    p.idle = kept
+}
@@ -0,0 +1,167 @@
+package proj
+
+import (
+    "errors"
+    "path/filepath"
+    "sync"
+    "testing"
+    "time"
+)
+
+// Tests :
+
+// TestContextPool checks contexts are reused and configured.
+func TestContextPool ( t *testing.T ) {
+    dir := t.TempDir()
+    lvl := Error
+    p := NewContextPool(PoolOptions{ContextOptions:ContextOptions{SearchPaths:[]string{dir}, LogLevel:&lvl}, MaxContexts:2})
+    c1, e := p.Get()
+    if e != nil {
+        t.Fatal(e)
+    }
+    if paths := c1.SearchPaths() ; len(paths) != 1 || paths[0] != dir {
+        t.Errorf("Expected search paths [%s], but got %v", dir, paths)
+    }
+    if c1.LogLevel() != Error {
+        t.Errorf("Expected log level %s, but got %s", Error, c1.LogLevel())
+    }
+    p.Put(c1)
+    c2, _ := p.Get()
+    if c2 != c1 {
+        t.Errorf("Expected the idle context to be reused")
+    }
+    c3, _ := p.Get()
+    if live, idle := p.Len() ; live != 2 || idle != 0 {
+        t.Errorf("Expected 2 live and 0 idle contexts, but got %d and %d", live, idle)
+    }
+    got := make(chan *Context)
+    go func () {
+        c, _ := p.Get() // waits for a context to be put back
+        got <- c
+    }()
+    select {
+    case <-got :
+        t.Fatalf("Expected Get to wait when MaxContexts is reached")
+    case <-time.After(50 * time.Millisecond) :
+    }
+    p.Put(c3)
+    if c := <-got ; c != c3 {
+        t.Errorf("Expected the context put back")
+    }
+    p.Put(c2)
+    p.Put(c3)
+    p.Close()
+    if live, idle := p.Len() ; live != 0 || idle != 0 {
+        t.Errorf("Expected no context once closed, but got %d live and %d idle", live, idle)
+    }
+    if !c1.HandleIsNil() {
+        t.Errorf("Expected contexts to be destroyed once the pool is closed")
+    }
+    if _, e = p.Get() ; !errors.Is(e, ErrPoolClosed) {
+        t.Errorf("Expected ErrPoolClosed, but got %v", e)
+    }
+}
+
+// TestContextPoolWith checks concurrent use through With.
+func TestContextPoolWith ( t *testing.T ) {
+    p := NewContextPool(PoolOptions{MaxContexts:4})
+    defer p.Close()
+    var wg sync.WaitGroup
+    for i := 0 ; i < 16 ; i++ {
+        wg.Add(1)
+        go func () {
+            defer wg.Done()
+            e := p.With(func ( c *Context ) error {
+                op, e := NewOperation(c, nil, "+proj=utm +zone=31 +ellps=WGS84")
+                if e != nil {
+                    return e
+                }
+                defer op.DestroyOperation()
+                _, e = op.Transform(Forward, NewCoordinate(0.05, 0.0))
+                return e
+            })
+            if e != nil {
+                t.Error(e)
+            }
+        }()
+    }
+    wg.Wait()
+    if live, _ := p.Len() ; live > 4 {
+        t.Errorf("Expected at most 4 live contexts, but got %d", live)
+    }
+}
+
+// TestContextPoolIdle checks idle contexts are closed.
+func TestContextPoolIdle ( t *testing.T ) {
+    p := NewContextPool(PoolOptions{IdleTimeout:20 * time.Millisecond})
+    defer p.Close()
+    c, _ := p.Get()
+    p.Put(c)
+    deadline := time.Now().Add(time.Second)
+    for time.Now().Before(deadline) {
+        if live, _ := p.Len() ; live == 0 {
+            break
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    if live, _ := p.Len() ; live != 0 || !c.HandleIsNil() {
+        t.Errorf("Expected the idle context to be closed, %d live", live)
+    }
+}
+
+// TestContextPoolPut checks changed and foreign contexts are not reused,
+// and that options which cannot be applied are reported.
+func TestContextPoolPut ( t *testing.T ) {
+    p := NewContextPool(PoolOptions{})
+    defer p.Close()
+    c, e := p.Get()
+    if e != nil {
+        t.Fatal(e)
+    }
+    c.SetLogger(func ( ctx *Context, lvl LoggingLevel, msg string ) {})
+    if e = p.Put(c) ; e != nil {
+        t.Fatal(e)
+    }
+    if live, idle := p.Len() ; live != 0 || idle != 0 || !c.HandleIsNil() {
+        t.Errorf("Expected the changed context to be closed, but got %d live and %d idle", live, idle)
+    }
+    f := NewContext()
+    defer f.DestroyContext()
+    if e = p.Put(f) ; !errors.Is(e, ErrForeignContext) {
+        t.Errorf("Expected ErrForeignContext, but got %v", e)
+    }
+    if f.HandleIsNil() {
+        t.Errorf("Expected the foreign context to be left alone")
+    }
+    b := NewContextPool(PoolOptions{ContextOptions:ContextOptions{DatabasePath:filepath.Join(t.TempDir(), "proj.db")}})
+    defer b.Close()
+    if _, e = b.Get() ; e == nil {
+        t.Errorf("Expected an error for a missing database")
+    }
+    if live, _ := b.Len() ; live != 0 {
+        t.Errorf("Expected no live context, but got %d", live)
+    }
+}
+
+// TestContextPoolOptionsCopy checks the pool keeps its own copy of the
+// options.
+func TestContextPoolOptionsCopy ( t *testing.T ) {
+    dir := t.TempDir()
+    lvl := Error
+    opts := PoolOptions{ContextOptions:ContextOptions{SearchPaths:[]string{dir}, LogLevel:&lvl}}
+    p := NewContextPool(opts)
+    defer p.Close()
+    opts.SearchPaths[0] = "/nowhere"
+    lvl = Debug
+    c, e := p.Get()
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer p.Put(c)
+    if paths := c.SearchPaths() ; len(paths) != 1 || paths[0] != dir {
+        t.Errorf("Expected search paths [%s], but got %v", dir, paths)
+    }
+    if c.LogLevel() != Error {
+        t.Errorf("Expected log level %s, but got %s", Error, c.LogLevel())
+    }
+}
@@ -8,13 +8,15 @@ package proj
 import "C"
 
 import (
-    "fmt"
+    "runtime"
 )
 
 // PrimeMeridian contains an internal object that holds everything related to a
 // given prime meridian.
 type PrimeMeridian struct {
-    pj *C.PJ
+    pj  *C.PJ
+    ctx *Context    // owning context
+    use usage       // concurrent use tracker
 }
 
 // NewPrimeMeridian creates a prime meridian from a WKT string or URI.
@@ -26,23 +28,43 @@ func NewPrimeMeridian (ctx *Context, def string ) ( pm *PrimeMeridian, e error )
         if C.proj_get_type(pj) != C.PJ_TYPE_PRIME_MERIDIAN {
             C.proj_destroy(pj)
             pj = nil
-            e = fmt.Errorf("%v does not yield a Prime Meridian", def)
+            e = newError(ctx, "NewPrimeMeridian", def, ErrNotAPrimeMeridian, "does not yield a Prime Meridian")
             return
         }
-        pm = &PrimeMeridian{pj:pj}
+        pm = newPrimeMeridian(ctx, pj)
     }
     return
 }
 
+// newPrimeMeridian wraps the PROJ pointer created in the given context.
+//
+func newPrimeMeridian ( ctx *Context, pj *C.PJ ) *PrimeMeridian {
+    pm := &PrimeMeridian{pj:pj, ctx:ctx}
+    allocated()
+    runtime.SetFinalizer(pm, func ( pm *PrimeMeridian ) {
+        leaked(pm)
+        destroyPJ(nil, (*pm).pj)
+    })
+    return pm
+}
+
 // DestroyPrimeMeridian deallocate the internal prime meridian object.
 //
 func (pm *PrimeMeridian) DestroyPrimeMeridian () {
     if (*pm).pj != nil {
-        C.proj_destroy((*pm).pj)
+        destroyPJ((*pm).ctx, (*pm).pj)
         (*pm).pj = nil
+        runtime.SetFinalizer(pm, nil)
     }
 }
 
+// Close deallocates the internal PrimeMeridian object. It implements io.Closer.
+//
+func (pm *PrimeMeridian) Close () error {
+    pm.DestroyPrimeMeridian()
+    return nil
+}
+
 // Handle returns the PROJ internal object to be passed to the PROJ library
 //
 func (pm *PrimeMeridian) Handle () (interface{}) {
@@ -55,6 +77,46 @@ func (pm *PrimeMeridian) HandleIsNil () bool {
     return (*pm).pj == (*C.PJ)(nil)
 }
 
+// Context returns the context owning the prime meridian.
+//
+func (pm *PrimeMeridian) Context () *Context {
+    return (*pm).ctx
+}
+
+// guard returns the concurrent use tracker of the prime meridian.
+//
+func (pm *PrimeMeridian) guard () *usage {
+    return &((*pm).use)
+}
+
+// Clone duplicates the prime meridian into the given context. The owning context
+// is used when `ctx` is nil.
+//
+func (pm *PrimeMeridian) Clone ( ctx *Context ) ( c *PrimeMeridian, e error ) {
+    if ctx == nil {
+        ctx = (*pm).ctx
+    }
+    defer pm.use.enter(pm, nil, nil)()
+    defer runtime.KeepAlive(pm)
+    var pj *C.PJ
+    if pj, e = clonePJ(ctx, (*pm).pj, "Prime Meridian") ; e != nil {
+        return
+    }
+    c = newPrimeMeridian(ctx, pj)
+    return
+}
+
+// AssignContext moves the prime meridian to the given context, which then owns
+// it. Must not be called while the prime meridian is in use. It panics when `ctx`
+// is nil.
+//
+func (pm *PrimeMeridian) AssignContext ( ctx *Context ) {
+    defer pm.use.enter(pm, nil, nil)()
+    defer runtime.KeepAlive(pm)
+    C.proj_assign_context((*pm).pj, (*ctx).pj)
+    (*pm).ctx = ctx
+}
+
 // TypeOf returns the ISOType of a prime meridian (PrimeMeridianType).
 // UnKnownType on error.
 //
@@ -65,6 +127,8 @@ func (pm *PrimeMeridian) TypeOf ( ) ISOType {
 // Longitude returns the longitude of the prime meridian.
 //
 func (pm *PrimeMeridian) Longitude ( ctx *Context ) ( longitude float64, e error ) {
+    defer pm.use.enter(pm, (*pm).ctx, ctx)()
+    defer runtime.KeepAlive(pm)
     _ = C.proj_errno_reset((*pm).pj)
     var cl C.double
     // proj_prime_meridian_get_parameters fails if pm is not a prime meridian ...
@@ -78,6 +142,8 @@ func (pm *PrimeMeridian) Longitude ( ctx *Context ) ( longitude float64, e error
 // the unit name of the given prime meridian.
 //
 func (pm *PrimeMeridian) ToRad ( ctx *Context ) ( toRad float64, e error ) {
+    defer pm.use.enter(pm, (*pm).ctx, ctx)()
+    defer runtime.KeepAlive(pm)
     _ = C.proj_errno_reset((*pm).pj)
     var cr C.double
     // proj_prime_meridian_get_parameters fails if pm is not a prime meridian ...
@@ -89,6 +155,8 @@ func (pm *PrimeMeridian) ToRad ( ctx *Context ) ( toRad float64, e error ) {
 // Unit returns the longitude native unit of the given prime meridian.
 //
 func (pm *PrimeMeridian) Unit ( ctx *Context ) ( u string, e error ) {
+    defer pm.use.enter(pm, (*pm).ctx, ctx)()
+    defer runtime.KeepAlive(pm)
     _ = C.proj_errno_reset((*pm).pj)
     var cu *C.char
     // proj_prime_meridian_get_parameters fails if pm is not a prime meridian ...
@@ -102,6 +170,8 @@ func (pm *PrimeMeridian) Unit ( ctx *Context ) ( u string, e error ) {
 // the unit name of the given prime meridian.
 //
 func (pm *PrimeMeridian) Parameters ( ctx *Context ) ( longitude float64, toRad float64, u string, e error ) {
+    defer pm.use.enter(pm, (*pm).ctx, ctx)()
+    defer runtime.KeepAlive(pm)
     _ = C.proj_errno_reset((*pm).pj)
     var cl, cr C.double
     var cu *C.char
@@ -116,6 +186,7 @@ func (pm *PrimeMeridian) Parameters ( ctx *Context ) ( longitude float64, toRad
 // Info returns information about a specific prime meridien object.
 //
 func (pm *PrimeMeridian) Info ( ) ( *ISOInfo ) {
+    defer runtime.KeepAlive(pm)
     return &ISOInfo{pj:C.proj_pj_info((*pm).pj)}
 }
 
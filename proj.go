@@ -13,6 +13,7 @@ package proj
 import "C"
 
 import (
+    "runtime"
     "unsafe"
     "strings"
     "fmt"
@@ -44,32 +45,110 @@ type pj interface {
     Info()                                                      *ISOInfo    // return information about a specific object
     ProjString( ctx *Context, styp StringType, opts ...string ) string      // return PROJ representation of a specific object
     Wkt( ctx *Context, styp WKTType, opts ...string )           string      // return WKT representation of a specific object
+    Context()                                                   *Context    // return the context owning a specific object
+    guard()                                                     *usage      // return the concurrent use tracker of a specific object
+}
+
+// ISOObject is implemented by the objects created from the database
+// without knowing their type beforehand : *ReferenceSystem, *Operation,
+// *Ellipsoid, *PrimeMeridian and *Datum. Use a type switch to get the actual
+// object :
+//
+//   switch o := obj.(type) {
+//   case *ReferenceSystem : ...
+//   case *Operation       : ...
+//   }
+//
+type ISOObject interface {
+    pj
+    Close() error                                                           // release the PROJ memory of a specific object
+}
+
+// newISOObject wraps the PROJ pointer into the Go type matching its type,
+// nil (after releasing the pointer) when there is none.
+//
+func newISOObject ( ctx *Context, pj *C.PJ ) ISOObject {
+    switch t := ISOType(C.proj_get_type(pj)) ; {
+    case t == EllipsoidType :
+        return newEllipsoid(ctx, pj)
+    case t == PrimeMeridianType :
+        return newPrimeMeridian(ctx, pj)
+    case t >= GeodeticReferenceFrame && t <= DatumEnsemble :
+        return newDatum(ctx, pj)
+    case t >= CRS && t <= OtherCRS :
+        return newReferenceSystem(ctx, pj)
+    case t >= Conversion && t <= OtherCoordinateOperation :
+        return newOperation(ctx, pj)
+    default :
+        C.proj_destroy(pj)
+        return nil
+    }
+}
+
+// pjConstructors names the constructors of each category, for errors.
+//
+var pjConstructors = map[C.PJ_CATEGORY]string{
+    C.PJ_CATEGORY_ELLIPSOID             : "NewEllipsoid",
+    C.PJ_CATEGORY_PRIME_MERIDIAN        : "NewPrimeMeridian",
+    C.PJ_CATEGORY_DATUM                 : "NewDatum",
+    C.PJ_CATEGORY_CRS                   : "NewReferenceSystem",
+    C.PJ_CATEGORY_COORDINATE_OPERATION  : "NewOperation",
+}
+
+// pjMismatches holds the sentinel error when a definition does not yield an
+// object of the expected category.
+//
+var pjMismatches = map[C.PJ_CATEGORY]error{
+    C.PJ_CATEGORY_ELLIPSOID             : ErrNotAnEllipsoid,
+    C.PJ_CATEGORY_PRIME_MERIDIAN        : ErrNotAPrimeMeridian,
+    C.PJ_CATEGORY_DATUM                 : ErrInvalidDefinition,
+    C.PJ_CATEGORY_CRS                   : ErrNotACRS,
+    C.PJ_CATEGORY_COORDINATE_OPERATION  : ErrNotAnOperation,
 }
 
 // NewPJ creates the PROJ pointer
 //
 func NewPJ (ctx *Context, def string, styp string, ctyp C.PJ_CATEGORY ) ( pj *C.PJ, e error) {
+    op := pjConstructors[ctyp]
     cdef := C.CString(def)
     defer C.free(unsafe.Pointer(cdef))
     switch dialect := C.proj_context_guess_wkt_dialect((*ctx).pj, cdef) ; GuessedWKTDialect(dialect) {
     case GuessedWKTUnknown  : // URI
         ac := strings.Split(def,":")
         switch len(ac) {
-        case 7 : // urn:ogc:def:<type>::<auth>:<code>
+        case 7 : // urn:ogc:def:<type>:<auth>:<version>:<code>
+            if ctyp == C.PJ_CATEGORY_CRS {
+                if pj = ctx.registered(ac[4], ac[6]) ; pj != nil {
+                    return
+                }
+            }
             pj = C.proj_create((*ctx).pj, cdef)
+            if pj == (*C.PJ)(nil) {
+                e = contextError(ctx, op, def, ErrUnknownAuthorityCode)
+                return
+            }
         case 2 : // <auth>:<code>
+            if ctyp == C.PJ_CATEGORY_CRS {
+                if pj = ctx.registered(ac[0], ac[1]) ; pj != nil {
+                    return
+                }
+            }
             cauth := C.CString(ac[0])
             defer C.free(unsafe.Pointer(cauth))
             cname := C.CString(ac[1])
             defer C.free(unsafe.Pointer(cname))
             pj = C.proj_create_from_database((*ctx).pj, cauth, cname, ctyp, 0, nil)
+            if pj == (*C.PJ)(nil) {
+                e = contextError(ctx, op, def, ErrUnknownAuthorityCode)
+                return
+            }
         default:
             switch ctyp {
             case C.PJ_CATEGORY_CRS, C.PJ_CATEGORY_COORDINATE_OPERATION :
                 // proj-string
                 pj = C.proj_create((*ctx).pj, cdef)
             default :
-                e = fmt.Errorf("%v does not yield an %s", def, styp)
+                e = newError(ctx, op, def, pjMismatches[ctyp], fmt.Sprintf("does not yield an %s", styp))
                 return
             }
         }
@@ -81,21 +160,30 @@ func NewPJ (ctx *Context, def string, styp string, ctyp C.PJ_CATEGORY ) ( pj *C.
                 cm := C.listcat(ce)
                 defer C.free(unsafe.Pointer(cm))
                 defer C.proj_string_list_destroy(ce)
-                e = fmt.Errorf(C.GoString(cm))
-                //return
+                e = newError(ctx, op, def, ErrInvalidDefinition, C.GoString(cm))
+                return
             }
-            // not needed :
-            //e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+            e = contextError(ctx, op, def, ErrInvalidDefinition)
             return
         }
     }
     if pj == (*C.PJ)(nil) {
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+        e = contextError(ctx, op, def, ErrInvalidDefinition)
         return
     }
     return
 }
 
+// clonePJ duplicates the PROJ pointer into the given context.
+//
+func clonePJ ( ctx *Context, pj *C.PJ, styp string ) ( c *C.PJ, e error ) {
+    c = C.proj_clone((*ctx).pj, pj)
+    if c == (*C.PJ)(nil) {
+        e = contextError(ctx, "Clone", styp, nil)
+    }
+    return
+}
+
 // toString returns a string representation of the struct implementing a pj
 // interface.
 //
@@ -106,6 +194,7 @@ func toString ( o pj ) string {
 // hasType returns the ISOType of the struct implementing a pj interface.
 //
 func hasType  ( o pj ) ISOType {
+    defer runtime.KeepAlive(o)
     return ISOType(C.proj_get_type( o.Handle().(*C.PJ) ))
 }
 
@@ -117,24 +206,11 @@ func hasType  ( o pj ) ISOType {
 //   "USE_APPROX_TMERC=YES" to add the +approx flag to +proj=tmerc or +proj=utm
 //
 func toProj ( ctx *Context, o pj, styp StringType, opts []string ) string {
-    var copts **C.char
-    l := len(opts)
-    if l > 0 {
-        copts = C.makeStringArray(C.size_t(l+1))
-        for i, opt := range opts {
-            copt := C.CString(opt)
-            C.setStringArrayItem(copts, C.size_t(i), copt)
-        }
-        C.setStringArrayItem(copts, C.size_t(l), nil)
-    }
-    cs := C.proj_as_proj_string((*ctx).pj, o.Handle().(*C.PJ), C.PJ_PROJ_STRING_TYPE(styp), copts)
-    if l > 0 {
-        for i := 0 ; i < l ; i++ {
-            C.free(unsafe.Pointer(C.getStringArrayItem(copts, C.size_t(i))))
-        }
-        C.destroyStringArray(&copts)
-    }
-    return C.GoString(cs)
+    defer o.guard().enter(o, o.Context(), ctx)()
+    defer runtime.KeepAlive(o)
+    return asString(opts, func ( copts **C.char ) *C.char {
+        return C.proj_as_proj_string((*ctx).pj, o.Handle().(*C.PJ), C.PJ_PROJ_STRING_TYPE(styp), copts)
+    })
 }
 
 // toWkt returns a WKT representation of the struct implementing a pj
@@ -154,24 +230,53 @@ func toProj ( ctx *Context, o pj, styp StringType, opts []string ) string {
 //   them unconditionally, and to NO will omit them unconditionally.
 //
 func toWkt ( ctx *Context, o pj, styp WKTType, opts []string ) string {
+    defer o.guard().enter(o, o.Context(), ctx)()
+    defer runtime.KeepAlive(o)
+    return asString(opts, func ( copts **C.char ) *C.char {
+        return C.proj_as_wkt((*ctx).pj, o.Handle().(*C.PJ), C.PJ_WKT_TYPE(styp), copts)
+    })
+}
+
+// toProjJSON returns a PROJJSON representation of the struct implementing a
+// pj interface.
+// Empty string is returned on error.
+// `opts` can hold the following strings :
+//
+//   "MULTILINE=YES" Defaults to YES
+//
+//   "INDENTATION_WIDTH=<number>" Defaults to 2 (when multiline output is on)
+//
+//   "SCHEMA=<url>" URL to PROJJSON schema. Can be set to empty string to
+//   disable it.
+//
+func toProjJSON ( ctx *Context, o pj, opts []string ) string {
+    defer o.guard().enter(o, o.Context(), ctx)()
+    defer runtime.KeepAlive(o)
+    return asString(opts, func ( copts **C.char ) *C.char {
+        return C.proj_as_projjson((*ctx).pj, o.Handle().(*C.PJ), copts)
+    })
+}
+
+// asString returns the string `as` builds from the options, given as a NULL
+// terminated array of C strings, NULL when there is no option.
+//
+func asString ( opts []string, as func ( copts **C.char ) *C.char ) string {
     var copts **C.char
     l := len(opts)
     if l > 0 {
         copts = C.makeStringArray(C.size_t(l+1))
         for i, opt := range opts {
-            copt := C.CString(opt)
-            C.setStringArrayItem(copts, C.size_t(i), copt)
+            C.setStringArrayItem(copts, C.size_t(i), C.CString(opt))
         }
         C.setStringArrayItem(copts, C.size_t(l), nil)
+        defer func () {
+            for i := 0 ; i < l ; i++ {
+                C.free(unsafe.Pointer(C.getStringArrayItem(copts, C.size_t(i))))
+            }
+            C.destroyStringArray(&copts)
+        }()
     }
-    cs := C.proj_as_wkt((*ctx).pj, o.Handle().(*C.PJ), C.PJ_WKT_TYPE(styp), copts)
-    if l > 0 {
-        for i := 0 ; i < l ; i++ {
-            C.free(unsafe.Pointer(C.getStringArrayItem(copts, C.size_t(i))))
-        }
-        C.destroyStringArray(&copts)
-    }
-    return C.GoString(cs)
+    return C.GoString(as(copts))
 }
 
 // init package initialisation
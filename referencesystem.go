@@ -8,7 +8,9 @@ package proj
 import "C"
 
 import (
+    "runtime"
     "unsafe"
+    "strings"
     "fmt"
 )
 
@@ -16,7 +18,9 @@ import (
 // reference system and derivatives.
 //
 type ReferenceSystem struct {
-    pj *C.PJ
+    pj  *C.PJ
+    ctx *Context    // owning context
+    use usage       // concurrent use tracker
 }
 
 // Direction applies transformation to observation - in forward or inverse direction
@@ -45,11 +49,12 @@ const (
 //   crs := NewReferenceSystem(ctx, "proj=utm", "zone=32", "ellps=GRS80", "type=crs")
 //
 func NewReferenceSystem ( ctx *Context, def ...string ) (crs *ReferenceSystem, e error) {
+    defer ctx.capture()(&e)
     var pj *C.PJ
     l := len(def)
     switch l {
     case 0 :
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(-1)))
+        e = errnoError(ctx, "NewReferenceSystem", "", -1, ErrInvalidDefinition)
         return
     case 1 :
         pj, e = NewPJ(ctx, def[0], "CRS", C.PJ_CATEGORY_CRS)
@@ -66,29 +71,49 @@ func NewReferenceSystem ( ctx *Context, def ...string ) (crs *ReferenceSystem, e
         }
         C.destroyStringArray(&defs)
         if pj == (*C.PJ)(nil) {
-            e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+            e = contextError(ctx, "NewReferenceSystem", strings.Join(def, " "), ErrInvalidDefinition)
             return
         }
     }
     if C.proj_is_crs(pj) == C.int(0) {
         C.proj_destroy(pj)
         pj = nil
-        e = fmt.Errorf("%v does not yield a CRS", def)
+        e = newError(ctx, "NewReferenceSystem", strings.Join(def, " "), ErrNotACRS, "does not yield a CRS")
         return
     }
-    crs = &ReferenceSystem{pj:pj}
+    crs = newReferenceSystem(ctx, pj)
     return
 }
 
+// newReferenceSystem wraps the PROJ pointer created in the given context.
+//
+func newReferenceSystem ( ctx *Context, pj *C.PJ ) *ReferenceSystem {
+    crs := &ReferenceSystem{pj:pj, ctx:ctx}
+    allocated()
+    runtime.SetFinalizer(crs, func ( crs *ReferenceSystem ) {
+        leaked(crs)
+        destroyPJ(nil, (*crs).pj)
+    })
+    return crs
+}
+
 // DestroyReferenceSystem deallocates the internal ReferenceSystem object.
 //
 func (crs *ReferenceSystem) DestroyReferenceSystem () {
     if (*crs).pj != nil {
-        C.proj_destroy((*crs).pj)
+        destroyPJ((*crs).ctx, (*crs).pj)
         (*crs).pj = nil
+        runtime.SetFinalizer(crs, nil)
     }
 }
 
+// Close deallocates the internal ReferenceSystem object. It implements io.Closer.
+//
+func (crs *ReferenceSystem) Close () error {
+    crs.DestroyReferenceSystem()
+    return nil
+}
+
 // Handle returns the PROJ internal object to be passed to the PROJ library
 // Cannot be tested against nil as it returns a pointer to a type, so use :
 //   if p.HandleIsNil() { ... }
@@ -103,6 +128,46 @@ func (crs *ReferenceSystem) HandleIsNil () bool {
     return (*crs).pj == (*C.PJ)(nil)
 }
 
+// Context returns the context owning the reference system.
+//
+func (crs *ReferenceSystem) Context () *Context {
+    return (*crs).ctx
+}
+
+// guard returns the concurrent use tracker of the reference system.
+//
+func (crs *ReferenceSystem) guard () *usage {
+    return &((*crs).use)
+}
+
+// Clone duplicates the reference system into the given context. The owning context
+// is used when `ctx` is nil.
+//
+func (crs *ReferenceSystem) Clone ( ctx *Context ) ( c *ReferenceSystem, e error ) {
+    if ctx == nil {
+        ctx = (*crs).ctx
+    }
+    defer crs.use.enter(crs, nil, nil)()
+    defer runtime.KeepAlive(crs)
+    var pj *C.PJ
+    if pj, e = clonePJ(ctx, (*crs).pj, "Reference System") ; e != nil {
+        return
+    }
+    c = newReferenceSystem(ctx, pj)
+    return
+}
+
+// AssignContext moves the reference system to the given context, which then owns
+// it. Must not be called while the reference system is in use. It panics when `ctx`
+// is nil.
+//
+func (crs *ReferenceSystem) AssignContext ( ctx *Context ) {
+    defer crs.use.enter(crs, nil, nil)()
+    defer runtime.KeepAlive(crs)
+    C.proj_assign_context((*crs).pj, (*ctx).pj)
+    (*crs).ctx = ctx
+}
+
 // TypeOf returns the ISOType of a reference system (GeodeticCRS,
 // GeocentricCRS, GeographicCRS, Geographic2DCRS, Geographic3DCRS,
 // VerticalCRS, ProjectedCRS, CompoundCRS, TemporalCRS, EngineeringCRS,
@@ -175,6 +240,11 @@ var (
 // the bounding box of the transformation.
 //
 func (crs *ReferenceSystem) NewOperation ( ctx *Context, targetCrs *ReferenceSystem, filter ...OperationFilter ) ( op *Operation, e error) {
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer targetCrs.use.enter(targetCrs, (*targetCrs).ctx, ctx)()
+    defer runtime.KeepAlive(crs)
+    defer runtime.KeepAlive(targetCrs)
+    defer ctx.capture()(&e)
     _ = C.proj_errno_reset((*crs).pj)
     var opFilter OperationFilter
     if len(filter) == 0 {
@@ -186,7 +256,7 @@ func (crs *ReferenceSystem) NewOperation ( ctx *Context, targetCrs *ReferenceSys
     defer C.free(unsafe.Pointer(cauth))
     opeFactory := C.proj_create_operation_factory_context((*ctx).pj, cauth)
     if opeFactory == (*C.PJ_OPERATION_FACTORY_CONTEXT)(nil) {// no more memory ??
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+        e = contextError(ctx, "NewOperation", crs.String(), nil)
         return
     }
     defer C.proj_operation_factory_context_destroy(opeFactory)
@@ -245,14 +315,14 @@ func (crs *ReferenceSystem) NewOperation ( ctx *Context, targetCrs *ReferenceSys
     }
     candidateCrs := C.proj_create_operations((*ctx).pj, (*crs).pj, (*targetCrs).pj, opeFactory)
     if candidateCrs == (*C.PJ_OBJ_LIST)(nil) {// one of the crs is not a CRS, no more memory
-        e = fmt.Errorf(C.GoString(C.proj_errno_string(C.proj_context_errno((*ctx).pj))))
+        e = contextError(ctx, "NewOperation", crs.String(), ErrNotACRS)
         return
     }
     defer C.proj_list_destroy(candidateCrs)
     if C.proj_list_get_count(candidateCrs) == 0 {
         crsS := C.GoString(C.proj_get_name((*crs).pj))
         crsT := C.GoString(C.proj_get_name((*targetCrs).pj))
-        e = fmt.Errorf("No operation found between '%s' and '%s'", crsS, crsT)
+        e = newError(ctx, "NewOperation", crsS, ErrNoOperation, fmt.Sprintf("No operation found between '%s' and '%s'", crsS, crsT))
         return
     }
     // return the first operation as the operations are sorted with the most
@@ -262,13 +332,14 @@ func (crs *ReferenceSystem) NewOperation ( ctx *Context, targetCrs *ReferenceSys
     // accuracy. Operations with unknown accuracy are sorted last, whatever
     // their area.
     // counting is done for 0 (not documented, but code says : result->objects[index]
-    op = &Operation{pj:C.proj_list_get((*ctx).pj, candidateCrs, C.int(0))}
+    op = newOperation(ctx, C.proj_list_get((*ctx).pj, candidateCrs, C.int(0)))
     return
 }
 
 // Info returns information about a specific reference system object.
 //
 func (crs *ReferenceSystem) Info ( ) ( *ISOInfo ) {
+    defer runtime.KeepAlive(crs)
     return &ISOInfo{pj:C.proj_pj_info((*crs).pj)}
 }
 
@@ -306,3 +377,18 @@ func (crs *ReferenceSystem) Wkt ( ctx *Context, styp WKTType, opts ...string ) s
     return toWkt(ctx, crs, styp, opts)
 }
 
+// ProjJSON returns a PROJJSON representation of the reference system.
+// Empty string is returned on error.
+// `opts` can hold the following strings :
+//
+//   "MULTILINE=YES" Defaults to YES
+//
+//   "INDENTATION_WIDTH=<number>" Defaults to 2 (when multiline output is on)
+//
+//   "SCHEMA=<url>" URL to PROJJSON schema. Can be set to empty string to
+//   disable it.
+//
+func (crs *ReferenceSystem) ProjJSON ( ctx *Context, opts ...string ) string {
+    return toProjJSON(ctx, crs, opts)
+}
+
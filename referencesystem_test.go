@@ -112,3 +112,34 @@ func TestEPSG2154 ( t *testing.T ) {
     p.DestroyReferenceSystem()
 }
 
+
+// TestCrsContext checks the owning context, cloning and moving between
+// contexts.
+func TestCrsContext ( t *testing.T ) {
+    s4326 := "EPSG:4326"
+    p, e := NewReferenceSystem(ctx, s4326)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer p.DestroyReferenceSystem()
+    if p.Context() != ctx {
+        t.Errorf("Expected '%s' to be owned by the tests context", s4326)
+    }
+    c := NewContext()
+    defer c.DestroyContext()
+    q, e := p.Clone(c)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer q.DestroyReferenceSystem()
+    if q.Context() != c {
+        t.Errorf("Expected the clone of '%s' to be owned by the new context", s4326)
+    }
+    if q.Wkt(c, WKTv2r2018) != p.Wkt(ctx, WKTv2r2018) {
+        t.Errorf("Expected the clone of '%s' to be identical", s4326)
+    }
+    q.AssignContext(ctx)
+    if q.Context() != ctx {
+        t.Errorf("Expected the clone of '%s' to be owned by the tests context", s4326)
+    }
+}
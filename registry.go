@@ -0,0 +1,172 @@
+package proj
+
+/*
+#cgo CFLAGS: -I. -I${SRCDIR}/usr/local/include
+#cgo LDFLAGS: -L${SRCDIR}/usr/local/lib -lproj
+#include "wrapper.h"
+ */
+import "C"
+
+import (
+    "runtime"
+    "sort"
+    "unsafe"
+)
+
+// Identification is a candidate identifier of a reference system.
+//
+type Identification struct {
+    // Authority is the authority name, e.g. "EPSG"
+    Authority   string
+    // Code is the code of the CRS within the authority, e.g. "4326"
+    Code        string
+    // Confidence is the confidence level, from 0 to 100
+    Confidence  int
+}
+
+// ID returns the "authority:code" identifier of the candidate.
+//
+func (i *Identification) ID () string {
+    return (*i).Authority + ":" + (*i).Code
+}
+
+// Register defines a CRS for the context without any database : once
+// registered, "authority:code" and the URN form resolve to the definition.
+// A code already registered is replaced. Authorities of the database, e.g.
+// "EPSG", are rejected so that a registered code never shadows one of the
+// database :
+//
+//   e := ctx.Register("ACME", "SITE7", wkt)
+//   crs, e := NewReferenceSystem(ctx, "ACME:SITE7")
+//
+func (ctx *Context) Register ( authority string, code string, def string ) error {
+    id := authority + ":" + code
+    if authority == "" || code == "" {
+        return newError(ctx, "Register", id, ErrInvalidDefinition, "Missing authority or code")
+    }
+    if ctx.lookupAuthorities()[authority] {
+        return newError(ctx, "Register", id, ErrInvalidDefinition, "Authority of the database")
+    }
+    crs, e := NewReferenceSystem(ctx, def)
+    if e != nil {
+        return e
+    }
+    defer crs.DestroyReferenceSystem()
+    pj, e := clonePJ(ctx, (*crs).pj, id)
+    if e != nil {
+        return e
+    }
+    if (*ctx).registry == nil {
+        (*ctx).registry = make(map[string]map[string]*C.PJ)
+    }
+    codes := (*ctx).registry[authority]
+    if codes == nil {
+        codes = make(map[string]*C.PJ)
+        (*ctx).registry[authority] = codes
+    }
+    if old := codes[code] ; old != nil {
+        C.proj_destroy(old)
+    }
+    codes[code] = pj
+    (*ctx).settings++
+    return nil
+}
+
+// Unregister removes a CRS given to `Register`.
+//
+func (ctx *Context) Unregister ( authority string, code string ) {
+    codes := (*ctx).registry[authority]
+    if pj := codes[code] ; pj != nil {
+        C.proj_destroy(pj)
+        delete(codes, code)
+        (*ctx).settings++
+        if len(codes) == 0 {
+            delete((*ctx).registry, authority)
+        }
+    }
+}
+
+// registered returns a copy of the CRS given to `Register`, nil when the
+// code is not registered.
+//
+func (ctx *Context) registered ( authority string, code string ) *C.PJ {
+    pj := (*ctx).registry[authority][code]
+    if pj == nil {
+        return nil
+    }
+    defer runtime.KeepAlive(ctx)
+    return C.proj_clone((*ctx).pj, pj)
+}
+
+// releaseRegistry destroys the CRS given to `Register`.
+//
+func (ctx *Context) releaseRegistry () {
+    for _, codes := range (*ctx).registry {
+        for _, pj := range codes {
+            C.proj_destroy(pj)
+        }
+    }
+    (*ctx).registry = nil
+}
+
+// Identify returns the identifiers of the CRS registered or in the database
+// matching the reference system, the most likely first. `authority` limits
+// the search to one authority, empty for all. Registered CRS equivalent to
+// the reference system come first with a confidence of 100.
+//
+//   ids, e := crs.Identify(ctx, "EPSG")
+//   if e == nil && len(ids) > 0 && ids[0].Confidence >= 70 {
+//       ...
+//   }
+//
+func (crs *ReferenceSystem) Identify ( ctx *Context, authority string ) ( ids []Identification, e error ) {
+    defer crs.use.enter(crs, (*crs).ctx, ctx)()
+    defer runtime.KeepAlive(ctx)
+    defer runtime.KeepAlive(crs)
+    for auth, codes := range (*ctx).registry {
+        if authority != "" && auth != authority {
+            continue
+        }
+        for code, pj := range codes {
+            if C.proj_is_equivalent_to_with_ctx((*ctx).pj, (*crs).pj, pj, C.PJ_COMP_EQUIVALENT) != 0 {
+                ids = append(ids, Identification{Authority:auth, Code:code, Confidence:100})
+            }
+        }
+    }
+    sort.Slice(ids, func ( i, j int ) bool { return ids[i].ID() < ids[j].ID() })
+    if authority != "" && !ctx.lookupAuthorities()[authority] {
+        return
+    }
+    var cauth *C.char
+    if authority != "" {
+        cauth = C.CString(authority)
+        defer C.free(unsafe.Pointer(cauth))
+    }
+    var confidences *C.int
+    l := C.proj_identify((*ctx).pj, (*crs).pj, cauth, nil, &confidences)
+    if l == nil {
+        e = contextError(ctx, "Identify", crs.String(), ErrNotACRS)
+        return
+    }
+    defer C.proj_list_destroy(l)
+    n := int(C.proj_list_get_count(l))
+    if confidences != nil {
+        defer C.proj_int_list_destroy(confidences)
+    }
+    for i := 0 ; i < n ; i++ {
+        pj := C.proj_list_get((*ctx).pj, l, C.int(i))
+        if pj == nil {
+            continue
+        }
+        id := Identification{
+            Authority:C.GoString(C.proj_get_id_auth_name(pj, 0)),
+            Code:C.GoString(C.proj_get_id_code(pj, 0)),
+        }
+        if confidences != nil {
+            id.Confidence = int(unsafe.Slice(confidences, n)[i])
+        }
+        C.proj_destroy(pj)
+        ids = append(ids, id)
+    }
+    return
+}
@@ -0,0 +1,72 @@
+package proj
+
+import (
+    "errors"
+    "testing"
+)
+
+// Tests :
+
+// TestRegister checks registered CRS are resolved and identified.
+func TestRegister ( t *testing.T ) {
+    c := NewContext()
+    defer c.DestroyContext()
+    site7 := "+proj=tmerc +lat_0=45 +lon_0=7 +k=0.9996 +x_0=500000 +y_0=0 +ellps=GRS80 +units=m +no_defs +type=crs"
+    if e := c.Register("ACME", "SITE7", site7) ; e != nil {
+        t.Fatal(e)
+    }
+    if e := c.Register("ACME", "BAD", "+proj=utm +zone=31 +ellps=WGS84") ; e == nil {
+        t.Errorf("Expected an error when registering an operation")
+    }
+    if e := c.Register("EPSG", "4326", site7) ; !errors.Is(e, ErrInvalidDefinition) {
+        t.Errorf("Expected EPSG:4326 not to be shadowed, but got %v", e)
+    }
+    if crs, e := NewReferenceSystem(c, "EPSG:4326") ; e != nil {
+        t.Errorf("Expected EPSG:4326 from the database, but got %v", e)
+    } else {
+        if crs.TypeOf() != Geographic2DCRS {
+            t.Errorf("Expected a geographic 2D CRS for EPSG:4326, but got %v", crs.TypeOf())
+        }
+        crs.DestroyReferenceSystem()
+    }
+    if !c.IsAnAuthority("ACME") {
+        t.Errorf("Expected ACME to be an authority")
+    }
+    found := false
+    for _, a := range c.Authorities() {
+        found = found || a == "ACME"
+    }
+    if !found {
+        t.Errorf("Expected ACME in %v", c.Authorities())
+    }
+    for _, def := range []string{"ACME:SITE7", "urn:ogc:def:crs:ACME::SITE7"} {
+        crs, e := NewReferenceSystem(c, def)
+        if e != nil {
+            t.Errorf("Expected %s to be resolved, but got %v", def, e)
+            continue
+        }
+        if crs.TypeOf() != ProjectedCRS {
+            t.Errorf("Expected a projected CRS for %s, but got %v", def, crs.TypeOf())
+        }
+        crs.DestroyReferenceSystem()
+    }
+    crs, e := NewReferenceSystem(c, site7)
+    if e != nil {
+        t.Fatal(e)
+    }
+    defer crs.DestroyReferenceSystem()
+    ids, e := crs.Identify(c, "ACME")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if len(ids) != 1 || ids[0].ID() != "ACME:SITE7" || ids[0].Confidence != 100 {
+        t.Errorf("Expected ACME:SITE7 with a confidence of 100, but got %v", ids)
+    }
+    c.Unregister("ACME", "SITE7")
+    if c.IsAnAuthority("ACME") {
+        t.Errorf("Expected ACME to be unregistered")
+    }
+    if _, e = NewReferenceSystem(c, "ACME:SITE7") ; e == nil {
+        t.Errorf("Unexpected ACME:SITE7 once unregistered")
+    }
+}
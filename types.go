@@ -246,6 +246,47 @@ const (
     TemporalMeasureCs CoordinateSystemType = C.PJ_CS_TYPE_TEMPORALMEASURE
 )
 
+// Cartesian2DType describes the axes of the usual Cartesian 2D coordinate
+// systems.
+//
+type Cartesian2DType C.PJ_CARTESIAN_CS_2D_TYPE
+const (
+    // EastingNorthing for easting then northing axes
+    EastingNorthing Cartesian2DType = C.PJ_CART2D_EASTING_NORTHING
+    // NorthingEasting for northing then easting axes
+    NorthingEasting Cartesian2DType = C.PJ_CART2D_NORTHING_EASTING
+    // NorthPoleEastingSouthNorthingSouth for north polar projections
+    NorthPoleEastingSouthNorthingSouth Cartesian2DType = C.PJ_CART2D_NORTH_POLE_EASTING_SOUTH_NORTHING_SOUTH
+    // SouthPoleEastingNorthNorthingNorth for south polar projections
+    SouthPoleEastingNorthNorthingNorth Cartesian2DType = C.PJ_CART2D_SOUTH_POLE_EASTING_NORTH_NORTHING_NORTH
+    // WestingSouthing for westing then southing axes
+    WestingSouthing Cartesian2DType = C.PJ_CART2D_WESTING_SOUTHING
+)
+
+// Ellipsoidal2DType describes the axes of the ellipsoidal 2D coordinate
+// systems.
+//
+type Ellipsoidal2DType C.PJ_ELLIPSOIDAL_CS_2D_TYPE
+const (
+    // LongitudeLatitude for longitude then latitude axes
+    LongitudeLatitude Ellipsoidal2DType = C.PJ_ELLPS2D_LONGITUDE_LATITUDE
+    // LatitudeLongitude for latitude then longitude axes (EPSG order)
+    LatitudeLongitude Ellipsoidal2DType = C.PJ_ELLPS2D_LATITUDE_LONGITUDE
+)
+
+// Ellipsoidal3DType describes the axes of the ellipsoidal 3D coordinate
+// systems.
+//
+type Ellipsoidal3DType C.PJ_ELLIPSOIDAL_CS_3D_TYPE
+const (
+    // LongitudeLatitudeHeight for longitude, latitude then ellipsoidal
+    // height axes
+    LongitudeLatitudeHeight Ellipsoidal3DType = C.PJ_ELLPS3D_LONGITUDE_LATITUDE_HEIGHT
+    // LatitudeLongitudeHeight for latitude, longitude then ellipsoidal
+    // height axes (EPSG order)
+    LatitudeLongitudeHeight Ellipsoidal3DType = C.PJ_ELLPS3D_LATITUDE_LONGITUDE_HEIGHT
+)
+
 /*
  * replaced by TypeOf()
 // Type returns the type of a `*ReferenceSystem`, `*Operation`, `Ellipsoid`,
@@ -6,50 +6,159 @@ package proj
 #include "wrapper.h"
  */
 import "C"
-import "fmt"
 
-// Unit contains data needed to define distance units
+import (
+    "fmt"
+    "runtime"
+    "strconv"
+    "unsafe"
+)
+
+// UnitCategory lists the kinds of quantity a unit measures.
+//
+type UnitCategory string
+const (
+    // UnknownUnitCategory stands for an unknown category
+    UnknownUnitCategory UnitCategory = "unknown"
+    // LinearUnit measures lengths, converted to meters
+    LinearUnit UnitCategory = "linear"
+    // AngularUnit measures angles, converted to radians
+    AngularUnit UnitCategory = "angular"
+    // ScaleUnit measures scale factors, converted to unity
+    ScaleUnit UnitCategory = "scale"
+    // TimeUnit measures durations, converted to seconds
+    TimeUnit UnitCategory = "time"
+    // ParametricUnit measures parametric values
+    ParametricUnit UnitCategory = "parametric"
+)
+
+// Unit contains data needed to define units of measure : linear and
+// angular units known by PROJ, as well as units of the database.
 //
 type Unit struct {
-    pj *C.PJ_UNITS
+    pj          *C.PJ_UNITS     // nil for units of the database
+    id          string
+    name        string
+    toSI        string          // text representation of factor
+    factor      float64         // conversion factor to the SI unit
+    category    UnitCategory
 }
 
 var (
     units map[string]*Unit
+    angularUnits map[string]*Unit
 )
 
-// GetUnitByID returns an ellipsoid from its identifier
+// GetUnitByID returns a linear unit from its identifier
 //
 func GetUnitByID ( id string ) (u *Unit, e error) {
     if u = units[id] ; u == nil {
-        e = fmt.Errorf("No Unit with that identifier '%s'", id)
+        e = newError(nil, "GetUnitByID", id, ErrInvalidParameter, "No unit with that identifier")
+    }
+    return
+}
+
+// GetAngularUnitByID returns an angular unit from its identifier, e.g.
+// "deg", "grad" or "rad".
+//
+func GetAngularUnitByID ( id string ) (u *Unit, e error) {
+    if u = angularUnits[id] ; u == nil {
+        e = newError(nil, "GetAngularUnitByID", id, ErrInvalidParameter, "No angular unit with that identifier")
     }
     return
 }
 
-// ID returns the keyword name of the unit.
+// UnitOfMeasure returns the unit of measure of the database from its
+// authority and code, e.g. the arc-second :
+//
+//   sec, e := ctx.UnitOfMeasure("EPSG", "9104")
+//
+func (ctx *Context) UnitOfMeasure ( authority string, code string ) ( *Unit, error ) {
+    defer runtime.KeepAlive(ctx)
+    auth := C.CString(authority)
+    defer C.free(unsafe.Pointer(auth))
+    ccode := C.CString(code)
+    defer C.free(unsafe.Pointer(ccode))
+    var name, category *C.char
+    var factor C.double
+    if C.proj_uom_get_info_from_database((*ctx).pj, auth, ccode, &name, &factor, &category) == C.int(0) {
+        return nil, contextError(ctx, "UnitOfMeasure", authority+":"+code, ErrUnknownAuthorityCode)
+    }
+    u := &Unit{
+        id:authority+":"+code,
+        name:C.GoString(name),
+        factor:float64(factor),
+        category:UnitCategory(C.GoString(category)),
+    }
+    u.toSI = strconv.FormatFloat(u.factor, 'g', -1, 64)
+    return u, nil
+}
+
+// newUnit wraps a unit of PROJ.
+//
+func newUnit ( pj *C.PJ_UNITS, category UnitCategory ) *Unit {
+    return &Unit{
+        pj:pj,
+        id:C.GoString((*pj).id),
+        name:C.GoString((*pj).name),
+        toSI:C.GoString((*pj).to_meter),
+        factor:float64((*pj).factor),
+        category:category,
+    }
+}
+
+// ID returns the keyword name of the unit, "authority:code" for units of
+// the database.
 //
 func (u *Unit) ID () string {
-    return C.GoString((*u).pj.id)
+    return (*u).id
 }
 
 // String returns a text representation of the factor that converts a given
-// unit to meters.
+// unit to meters (to radians for angular units).
 //
 func (u *Unit) String () string {
-    return C.GoString((*u).pj.to_meter)
+    return (*u).toSI
 }
 
-// ToMeter returns the conversion factor that converts the unit to meters.
+// ToMeter returns the conversion factor that converts the unit to meters
+// (to radians for angular units).
 //
 func (u *Unit) ToMeter () float64 {
-    return float64((*u).pj.factor)
+    return (*u).factor
+}
+
+// ToSI returns the conversion factor that converts the unit to the SI unit
+// of its category (meter, radian, unity, second).
+//
+func (u *Unit) ToSI () float64 {
+    return (*u).factor
+}
+
+// Category returns the kind of quantity the unit measures.
+//
+func (u *Unit) Category () UnitCategory {
+    return (*u).category
 }
 
 // Name returns the name of the unit.
 //
 func (u *Unit) Name () string {
-    return C.GoString((*u).pj.name)
+    return (*u).name
+}
+
+// Convert converts a value expressed in the unit into `to` unit, e.g. US
+// survey feet into meters. It fails when both units do not measure the same
+// kind of quantity.
+//
+func (u *Unit) Convert ( value float64, to *Unit ) ( float64, error ) {
+    if (*u).category != (*to).category || (*u).category == UnknownUnitCategory {
+        return 0.0, newError(nil, "Convert", u.Name(), ErrInvalidParameter, fmt.Sprintf("Cannot convert %s unit '%s' into %s unit '%s'", (*u).category, u.Name(), (*to).category, to.Name()))
+    }
+    if (*u).factor == (*to).factor {
+        return value, nil
+    }
+    return value * (*u).factor / (*to).factor, nil
 }
 
 // Handle returns the PROJ internal object to be passed to the PROJ library
@@ -70,9 +179,14 @@ func init () {
     lus := int(C.nbUnitsFromPROJ())
     units = make(map[string]*Unit)
     for i := 0 ; i < lus ; i++ {
-        u := &Unit{pj:nil}
-        (*u).pj = C.getUnitFromPROJ(C.int(i))
+        u := newUnit(C.getUnitFromPROJ(C.int(i)), LinearUnit)
         units[u.ID()] = u
     }
+    laus := int(C.nbAngularUnitsFromPROJ())
+    angularUnits = make(map[string]*Unit)
+    for i := 0 ; i < laus ; i++ {
+        u := newUnit(C.getAngularUnitFromPROJ(C.int(i)), AngularUnit)
+        angularUnits[u.ID()] = u
+    }
 }
 
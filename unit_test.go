@@ -1,8 +1,10 @@
 package proj
 
 import (
+    "errors"
     "testing"
     "reflect"
+    "math"
 )
 
 // Tests :
@@ -32,3 +34,58 @@ func TestUnknownUnit ( t *testing.T ) {
     }
 }
 
+
+// TestAngularUnits checks angular units are loaded.
+func TestAngularUnits ( t *testing.T ) {
+    grad, e := GetAngularUnitByID("grad")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if grad.Category() != AngularUnit {
+        t.Errorf("Expected '%s' category, but got '%s'", AngularUnit, grad.Category())
+    }
+    deg, e := GetAngularUnitByID("deg")
+    if e != nil {
+        t.Fatal(e)
+    }
+    v, e := grad.Convert(100.0, deg)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if math.Abs(v - 90.0) > 1e-12 {
+        t.Errorf("Expected 100 grads to be 90 degrees, but got %f", v)
+    }
+    if _, e = GetAngularUnitByID("m") ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Unexpected 'm' angular unit")
+    }
+}
+
+// TestUnitOfMeasure checks units of the database.
+func TestUnitOfMeasure ( t *testing.T ) {
+    sec, e := ctx.UnitOfMeasure("EPSG", "9104")
+    if e != nil {
+        t.Fatal(e)
+    }
+    if sec.Category() != AngularUnit || sec.ID() != "EPSG:9104" || sec.Name() == "" {
+        t.Errorf("Unexpected arc-second unit %s '%s' (%s)", sec.ID(), sec.Name(), sec.Category())
+    }
+    deg, _ := GetAngularUnitByID("deg")
+    v, e := sec.Convert(3600.0, deg)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if math.Abs(v - 1.0) > 1e-12 {
+        t.Errorf("Expected 3600 arc-seconds to be 1 degree, but got %f", v)
+    }
+    usft, _ := GetUnitByID("us-ft")
+    m, _ := GetUnitByID("m")
+    if v, e = usft.Convert(3937.0, m) ; e != nil || math.Abs(v - 1200.0) > 1e-9 {
+        t.Errorf("Expected 3937 US survey feet to be 1200 m, but got %f (%v)", v, e)
+    }
+    if _, e = usft.Convert(1.0, deg) ; !errors.Is(e, ErrInvalidParameter) {
+        t.Errorf("Expected an error converting feet into degrees")
+    }
+    if _, e = ctx.UnitOfMeasure("EPSG", "0") ; e == nil {
+        t.Errorf("Expected an error for EPSG:0 unit")
+    }
+}
@@ -0,0 +1,450 @@
+package proj
+
+import (
+    "encoding/json"
+    "fmt"
+    "math"
+    "reflect"
+    "strconv"
+    "strings"
+)
+
+// CoordinatePrecision is the number of decimals used when formatting typed
+// coordinates with %v and %s verbs, as text or JSON. A negative value uses
+// the smallest number of digits necessary to represent the values exactly.
+// A precision given to a verb (e.g. %.3v) takes over.
+//
+var CoordinatePrecision = -1
+
+// Geographic holds the components of a geographic coordinate, in the unit
+// of the underlying CRS or operation (radians for PROJ classic operations).
+//
+type Geographic struct {
+    Lon float64 // longitude (λ)
+    Lat float64 // latitude (φ)
+    H   float64 // ellipsoidal height
+    T   float64 // time
+}
+
+// Projected holds the components of a projected coordinate.
+//
+type Projected struct {
+    E   float64 // easting
+    N   float64 // northing
+    H   float64 // height
+    T   float64 // time
+}
+
+// Geocentric holds the components of a geocentric (cartesian) coordinate.
+//
+type Geocentric struct {
+    X   float64
+    Y   float64
+    Z   float64
+    T   float64 // time
+}
+
+// Angular holds the three rotation angles of a coordinate.
+//
+type Angular struct {
+    Omega   float64 // first rotation angle (ω)
+    Phi     float64 // second rotation angle (φ)
+    Kappa   float64 // third rotation angle (κ)
+}
+
+var (
+    geographicNames = []string{"lon", "lat", "h", "t"}
+    projectedNames  = []string{"e", "n", "h", "t"}
+    geocentricNames = []string{"x", "y", "z", "t"}
+    angularNames    = []string{"omega", "phi", "kappa"}
+)
+
+// Geographic returns the geographic view of the coordinate.
+//
+func (c *Coordinate) Geographic () Geographic {
+    x, y, z, t := c.Components4D()
+    return Geographic{Lon:x, Lat:y, H:z, T:t}
+}
+
+// Projected returns the projected view of the coordinate.
+//
+func (c *Coordinate) Projected () Projected {
+    x, y, z, t := c.Components4D()
+    return Projected{E:x, N:y, H:z, T:t}
+}
+
+// Geocentric returns the geocentric view of the coordinate.
+//
+func (c *Coordinate) Geocentric () Geocentric {
+    x, y, z, t := c.Components4D()
+    return Geocentric{X:x, Y:y, Z:z, T:t}
+}
+
+// Angular returns the rotation angles view of the coordinate.
+//
+func (c *Coordinate) Angular () Angular {
+    x, y, z := c.Components3D()
+    return Angular{Omega:x, Phi:y, Kappa:z}
+}
+
+// String returns the components of the coordinate, e.g. "2.35 48.85 0 0".
+//
+func (c *Coordinate) String () string {
+    x, y, z, t := c.Components4D()
+    return formatValues([]float64{x, y, z, t}, 'f', CoordinatePrecision, nil, " ")
+}
+
+// Coordinate returns the coordinate holding the components.
+//
+func (g Geographic) Coordinate () *Coordinate {
+    return NewCoordinate(g.Lon, g.Lat, g.H, g.T)
+}
+
+// Location returns the coordinate holding the components. Together with
+// SetLocation, it implements `Locatable`.
+//
+func (g *Geographic) Location () *Coordinate {
+    return g.Coordinate()
+}
+
+// SetLocation assigns the components from the coordinate.
+//
+func (g *Geographic) SetLocation ( c *Coordinate ) {
+    *g = c.Geographic()
+}
+
+// String returns the components, e.g. "2.35 48.85 0 0".
+//
+func (g Geographic) String () string {
+    return fmt.Sprint(g)
+}
+
+// Format implements fmt.Formatter (see `formatComponents`).
+//
+func (g Geographic) Format ( f fmt.State, verb rune ) {
+    formatComponents(f, verb, g, geographicNames, []float64{g.Lon, g.Lat, g.H, g.T})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+func (g Geographic) MarshalText () ( []byte, error ) {
+    return marshalText([]float64{g.Lon, g.Lat, g.H, g.T})
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+func (g *Geographic) UnmarshalText ( text []byte ) error {
+    return unmarshalText(text, &g.Lon, &g.Lat, &g.H, &g.T)
+}
+
+// MarshalJSON implements json.Marshaler, e.g.
+// {"lon":2.35,"lat":48.85,"h":0,"t":0}.
+//
+func (g Geographic) MarshalJSON () ( []byte, error ) {
+    return marshalJSON(geographicNames, []float64{g.Lon, g.Lat, g.H, g.T})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+func (g *Geographic) UnmarshalJSON ( data []byte ) error {
+    return unmarshalJSON(data, geographicNames, &g.Lon, &g.Lat, &g.H, &g.T)
+}
+
+// Coordinate returns the coordinate holding the components.
+//
+func (p Projected) Coordinate () *Coordinate {
+    return NewCoordinate(p.E, p.N, p.H, p.T)
+}
+
+// Location returns the coordinate holding the components. Together with
+// SetLocation, it implements `Locatable`.
+//
+func (p *Projected) Location () *Coordinate {
+    return p.Coordinate()
+}
+
+// SetLocation assigns the components from the coordinate.
+//
+func (p *Projected) SetLocation ( c *Coordinate ) {
+    *p = c.Projected()
+}
+
+// String returns the components, e.g. "652216.64 6861682.61 0 0".
+//
+func (p Projected) String () string {
+    return fmt.Sprint(p)
+}
+
+// Format implements fmt.Formatter (see `formatComponents`).
+//
+func (p Projected) Format ( f fmt.State, verb rune ) {
+    formatComponents(f, verb, p, projectedNames, []float64{p.E, p.N, p.H, p.T})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+func (p Projected) MarshalText () ( []byte, error ) {
+    return marshalText([]float64{p.E, p.N, p.H, p.T})
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+func (p *Projected) UnmarshalText ( text []byte ) error {
+    return unmarshalText(text, &p.E, &p.N, &p.H, &p.T)
+}
+
+// MarshalJSON implements json.Marshaler, e.g.
+// {"e":652216.64,"n":6861682.61,"h":0,"t":0}.
+//
+func (p Projected) MarshalJSON () ( []byte, error ) {
+    return marshalJSON(projectedNames, []float64{p.E, p.N, p.H, p.T})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+func (p *Projected) UnmarshalJSON ( data []byte ) error {
+    return unmarshalJSON(data, projectedNames, &p.E, &p.N, &p.H, &p.T)
+}
+
+// Coordinate returns the coordinate holding the components.
+//
+func (g Geocentric) Coordinate () *Coordinate {
+    return NewCoordinate(g.X, g.Y, g.Z, g.T)
+}
+
+// Location returns the coordinate holding the components. Together with
+// SetLocation, it implements `Locatable`.
+//
+func (g *Geocentric) Location () *Coordinate {
+    return g.Coordinate()
+}
+
+// SetLocation assigns the components from the coordinate.
+//
+func (g *Geocentric) SetLocation ( c *Coordinate ) {
+    *g = c.Geocentric()
+}
+
+// String returns the components, e.g. "4201576.9 168903.8 4780198.8 0".
+//
+func (g Geocentric) String () string {
+    return fmt.Sprint(g)
+}
+
+// Format implements fmt.Formatter (see `formatComponents`).
+//
+func (g Geocentric) Format ( f fmt.State, verb rune ) {
+    formatComponents(f, verb, g, geocentricNames, []float64{g.X, g.Y, g.Z, g.T})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+func (g Geocentric) MarshalText () ( []byte, error ) {
+    return marshalText([]float64{g.X, g.Y, g.Z, g.T})
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+func (g *Geocentric) UnmarshalText ( text []byte ) error {
+    return unmarshalText(text, &g.X, &g.Y, &g.Z, &g.T)
+}
+
+// MarshalJSON implements json.Marshaler, e.g.
+// {"x":4201576.9,"y":168903.8,"z":4780198.8,"t":0}.
+//
+func (g Geocentric) MarshalJSON () ( []byte, error ) {
+    return marshalJSON(geocentricNames, []float64{g.X, g.Y, g.Z, g.T})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+func (g *Geocentric) UnmarshalJSON ( data []byte ) error {
+    return unmarshalJSON(data, geocentricNames, &g.X, &g.Y, &g.Z, &g.T)
+}
+
+// Coordinate returns the coordinate holding the rotation angles.
+//
+func (a Angular) Coordinate () *Coordinate {
+    return NewCoordinate(a.Omega, a.Phi, a.Kappa)
+}
+
+// Location returns the coordinate holding the rotation angles. Together
+// with SetLocation, it implements `Locatable`.
+//
+func (a *Angular) Location () *Coordinate {
+    return a.Coordinate()
+}
+
+// SetLocation assigns the rotation angles from the coordinate.
+//
+func (a *Angular) SetLocation ( c *Coordinate ) {
+    *a = c.Angular()
+}
+
+// String returns the rotation angles, e.g. "0.1 0.2 0.3".
+//
+func (a Angular) String () string {
+    return fmt.Sprint(a)
+}
+
+// Format implements fmt.Formatter (see `formatComponents`).
+//
+func (a Angular) Format ( f fmt.State, verb rune ) {
+    formatComponents(f, verb, a, angularNames, []float64{a.Omega, a.Phi, a.Kappa})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+func (a Angular) MarshalText () ( []byte, error ) {
+    return marshalText([]float64{a.Omega, a.Phi, a.Kappa})
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+func (a *Angular) UnmarshalText ( text []byte ) error {
+    return unmarshalText(text, &a.Omega, &a.Phi, &a.Kappa)
+}
+
+// MarshalJSON implements json.Marshaler, e.g.
+// {"omega":0.1,"phi":0.2,"kappa":0.3}.
+//
+func (a Angular) MarshalJSON () ( []byte, error ) {
+    return marshalJSON(angularNames, []float64{a.Omega, a.Phi, a.Kappa})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+func (a *Angular) UnmarshalJSON ( data []byte ) error {
+    return unmarshalJSON(data, angularNames, &a.Omega, &a.Phi, &a.Kappa)
+}
+
+// formatValues formats the values with the `fmtc` format (see
+// strconv.FormatFloat) and precision, prefixed by their name when `names` is
+// not nil, separated by `sep`.
+//
+func formatValues ( values []float64, fmtc byte, prec int, names []string, sep string ) string {
+    s := make([]string, len(values))
+    for i, v := range values {
+        s[i] = strconv.FormatFloat(v, fmtc, prec, 64)
+        if names != nil {
+            s[i] = names[i] + "=" + s[i]
+        }
+    }
+    return strings.Join(s, sep)
+}
+
+// formatComponents implements fmt.Formatter for typed coordinates :
+//
+//   %v, %s         components separated by a space, using CoordinatePrecision
+//
+//   %+v            components prefixed by their name, e.g. "lon=2.35 lat=48.85 h=0 t=0"
+//
+//   %e, %f, %g     components formatted as floats, e.g. "%.2f"
+//
+// A precision given to the verb overrides the default one.
+//
+func formatComponents ( f fmt.State, verb rune, o interface{}, names []string, values []float64 ) {
+    prec, hasPrec := f.Precision()
+    var fmtc byte
+    switch verb {
+    case 'v', 's' :
+        fmtc = 'f'
+        if !hasPrec {
+            prec = CoordinatePrecision
+        }
+        if verb == 'v' && f.Flag('+') {
+            fmt.Fprint(f, formatValues(values, fmtc, prec, names, " "))
+            return
+        }
+    case 'e', 'E', 'f', 'F', 'g', 'G' :
+        fmtc = byte(verb)
+        if verb == 'F' {
+            fmtc = 'f'
+        }
+        if !hasPrec {
+            prec = -1
+            if verb != 'g' && verb != 'G' {
+                prec = 6
+            }
+        }
+    default :
+        fmt.Fprintf(f, "%%!%c(%T=%s)", verb, o, formatValues(values, 'f', -1, nil, " "))
+        return
+    }
+    fmt.Fprint(f, formatValues(values, fmtc, prec, nil, " "))
+}
+
+// marshalText formats the values separated by a space.
+//
+func marshalText ( values []float64 ) ( []byte, error ) {
+    return []byte(formatValues(values, 'f', CoordinatePrecision, nil, " ")), nil
+}
+
+// unmarshalText parses values separated by spaces. Missing trailing values
+// are set to 0.
+//
+func unmarshalText ( text []byte, values ...*float64 ) error {
+    fields := strings.Fields(string(text))
+    if len(fields) == 0 || len(fields) > len(values) {
+        return fmt.Errorf("Expected 1 to %d components, but got '%s'", len(values), text)
+    }
+    for i, v := range values {
+        *v = 0.0
+        if i < len(fields) {
+            f, e := strconv.ParseFloat(fields[i], 64)
+            if e != nil {
+                return e
+            }
+            *v = f
+        }
+    }
+    return nil
+}
+
+// marshalJSON formats the values as a JSON object whose keys are `names`.
+// Like encoding/json, it fails on NaN and infinite values, e.g. those of
+// failed transforms, JSON having no representation for them.
+//
+func marshalJSON ( names []string, values []float64 ) ( []byte, error ) {
+    var b strings.Builder
+    b.WriteByte('{')
+    for i, v := range values {
+        if math.IsNaN(v) || math.IsInf(v, 0) {
+            return nil, &json.UnsupportedValueError{Value:reflect.ValueOf(v), Str:strconv.FormatFloat(v, 'g', -1, 64)}
+        }
+        if i > 0 {
+            b.WriteByte(',')
+        }
+        b.WriteString(strconv.Quote(names[i]))
+        b.WriteByte(':')
+        b.WriteString(strconv.FormatFloat(v, 'f', CoordinatePrecision, 64))
+    }
+    b.WriteByte('}')
+    return []byte(b.String()), nil
+}
+
+// unmarshalJSON parses a JSON object whose keys are `names`. Missing keys
+// are set to 0, unknown keys are an error.
+//
+func unmarshalJSON ( data []byte, names []string, values ...*float64 ) error {
+    var m map[string]float64
+    if e := json.Unmarshal(data, &m) ; e != nil {
+        return e
+    }
+    for k := range m {
+        known := false
+        for _, n := range names {
+            if k == n {
+                known = true
+                break
+            }
+        }
+        if !known {
+            return fmt.Errorf("Unexpected key '%s', expected one of %v", k, names)
+        }
+    }
+    for i, v := range values {
+        *v = m[names[i]]
+    }
+    return nil
+}
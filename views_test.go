@@ -0,0 +1,115 @@
+package proj
+
+import (
+    "encoding/json"
+    "fmt"
+    "math"
+    "testing"
+)
+
+// Tests :
+
+// TestGeographic checks conversions from and to Coordinate.
+func TestGeographic ( t *testing.T ) {
+    c := NewCoordinate(2.35, 48.85, 35.0, 2020.5)
+    g := c.Geographic()
+    if g.Lon != 2.35 || g.Lat != 48.85 || g.H != 35.0 || g.T != 2020.5 {
+        t.Errorf("Expected {2.35 48.85 35 2020.5}, but got %+v", g)
+    }
+    x, y, z, tt := g.Coordinate().Components4D()
+    if x != 2.35 || y != 48.85 || z != 35.0 || tt != 2020.5 {
+        t.Errorf("Expected (2.35, 48.85, 35, 2020.5), but got (%g, %g, %g, %g)", x, y, z, tt)
+    }
+    var l Locatable = &g
+    l.SetLocation(NewCoordinate(1.0, 2.0))
+    if g.Lon != 1.0 || g.Lat != 2.0 || g.H != 0.0 {
+        t.Errorf("Expected {1 2 0 0}, but got %v", g)
+    }
+}
+
+// TestViewsFormat checks fmt verbs and precision.
+func TestViewsFormat ( t *testing.T ) {
+    p := Projected{E:652216.6425, N:6861682.61, H:0.0, T:0.0}
+    tests := []struct{ format, expected string }{
+        {"%v", "652216.6425 6861682.61 0 0"},
+        {"%s", "652216.6425 6861682.61 0 0"},
+        {"%.2v", "652216.64 6861682.61 0.00 0.00"},
+        {"%+v", "e=652216.6425 n=6861682.61 h=0 t=0"},
+        {"%.1f", "652216.6 6861682.6 0.0 0.0"},
+        {"%d", "%!d(proj.Projected=652216.6425 6861682.61 0 0)"},
+    }
+    for _, test := range tests {
+        if s := fmt.Sprintf(test.format, p); s != test.expected {
+            t.Errorf("%s: expected '%s', but got '%s'", test.format, test.expected, s)
+        }
+    }
+    a := Angular{Omega:0.1, Phi:0.2, Kappa:0.3}
+    if s := a.String(); s != "0.1 0.2 0.3" {
+        t.Errorf("Expected '0.1 0.2 0.3', but got '%s'", s)
+    }
+    defer func ( prec int ) { CoordinatePrecision = prec }(CoordinatePrecision)
+    CoordinatePrecision = 1
+    if s := a.String(); s != "0.1 0.2 0.3" {
+        t.Errorf("Expected '0.1 0.2 0.3', but got '%s'", s)
+    }
+    if s := NewCoordinate(1.25, 2.0).String(); s != "1.2 2.0 0.0 0.0" {
+        t.Errorf("Expected '1.2 2.0 0.0 0.0', but got '%s'", s)
+    }
+}
+
+// TestViewsJSON checks JSON marshalling round trips.
+func TestViewsJSON ( t *testing.T ) {
+    g := Geocentric{X:4201576.9, Y:168903.8, Z:4780198.8}
+    b, e := json.Marshal(g)
+    if e != nil {
+        t.Fatal(e)
+    }
+    if string(b) != `{"x":4201576.9,"y":168903.8,"z":4780198.8,"t":0}` {
+        t.Errorf("Unexpected JSON %s", b)
+    }
+    var r Geocentric
+    if e = json.Unmarshal(b, &r); e != nil {
+        t.Fatal(e)
+    }
+    if r != g {
+        t.Errorf("Expected %v, but got %v", g, r)
+    }
+    var a Angular
+    if e = json.Unmarshal([]byte(`{"omega":1,"kappa":3}`), &a); e != nil {
+        t.Fatal(e)
+    }
+    if a != (Angular{Omega:1.0, Kappa:3.0}) {
+        t.Errorf("Expected {1 0 3}, but got %v", a)
+    }
+    if e = json.Unmarshal([]byte(`{"omega":1,"kapa":3}`), &a); e == nil {
+        t.Errorf("Expected an error for an unknown key")
+    }
+    if b, e = json.Marshal(Geographic{Lon:math.Inf(1), Lat:math.NaN()}) ; e == nil {
+        t.Errorf("Expected an error for non-finite values, but got %s", b)
+    }
+}
+
+// TestViewsText checks text marshalling round trips.
+func TestViewsText ( t *testing.T ) {
+    g := Geographic{Lon:2.35, Lat:48.85}
+    b, e := g.MarshalText()
+    if e != nil {
+        t.Fatal(e)
+    }
+    var r Geographic
+    if e = r.UnmarshalText(b); e != nil {
+        t.Fatal(e)
+    }
+    if r != g {
+        t.Errorf("Expected %v, but got %v", g, r)
+    }
+    if e = r.UnmarshalText([]byte("1 2")); e != nil || r != (Geographic{Lon:1.0, Lat:2.0}) {
+        t.Errorf("Expected {1 2 0 0}, but got %v (%v)", r, e)
+    }
+    if e = r.UnmarshalText([]byte("1 2 3 4 5")); e == nil {
+        t.Errorf("Expected an error with 5 components")
+    }
+    if e = r.UnmarshalText([]byte("1 x")); e == nil {
+        t.Errorf("Expected an error with a non numeric component")
+    }
+}
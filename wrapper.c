@@ -34,6 +34,10 @@ char *getAuthorityFromPROJ ( PROJ_STRING_LIST l, int i ) {
     return l[i];
 }
 
+PROJ_CRS_INFO *getCRSInfoFromPROJ ( PROJ_CRS_INFO **l, int i ) {
+    return l[i];
+}
+
 char *listcat ( PROJ_STRING_LIST sl ) {
     size_t l = 0;
     char *result = NULL;
@@ -83,16 +87,90 @@ PJ_UNITS *getUnitFromPROJ ( int i ) {
     return us+i;
 }   
 
+int nbAngularUnitsFromPROJ ( ) {
+    int n = 0 ;
+    PJ_UNITS *us;
+    for (us = (PJ_UNITS *)proj_list_angular_units(); us->id; us++) { n++; }
+    return n;
+}
+
+PJ_UNITS *getAngularUnitFromPROJ ( int i ) {
+    PJ_UNITS *us;
+    us = (PJ_UNITS *)proj_list_angular_units();
+    return us+i;
+}
+
+int nbEllipsoidsFromPROJ ( ) {
+    int n = 0 ;
+    PJ_ELLPS *es;
+    for (es = (PJ_ELLPS *)proj_list_ellps(); es->id; es++) { n++; }
+    return n;
+}
+
+PJ_ELLPS *getEllipsoidFromPROJ ( int i ) {
+    PJ_ELLPS *es;
+    es = (PJ_ELLPS *)proj_list_ellps();
+    return es+i;
+}
+
+int nbPrimeMeridiansFromPROJ ( ) {
+    int n = 0 ;
+    PJ_PRIME_MERIDIANS *pms;
+    for (pms = (PJ_PRIME_MERIDIANS *)proj_list_prime_meridians(); pms->id; pms++) { n++; }
+    return n;
+}
+
+PJ_PRIME_MERIDIANS *getPrimeMeridianFromPROJ ( int i ) {
+    PJ_PRIME_MERIDIANS *pms;
+    pms = (PJ_PRIME_MERIDIANS *)proj_list_prime_meridians();
+    return pms+i;
+}
+
+int nbOperationsFromPROJ ( ) {
+    int n = 0 ;
+    PJ_OPERATIONS *ops;
+    for (ops = (PJ_OPERATIONS *)proj_list_operations(); ops->id; ops++) { n++; }
+    return n;
+}
+
+PJ_OPERATIONS *getOperationFromPROJ ( int i ) {
+    PJ_OPERATIONS *ops;
+    ops = (PJ_OPERATIONS *)proj_list_operations();
+    return ops+i;
+}
+
+const char *getOperationDescriptionFromPROJ ( PJ_OPERATIONS *op ) {
+    if (op->descr == NULL) return NULL;
+    return *(op->descr);
+}
+
 void logFuncToGo ( void *udata, int llvl, const char *emsg ) {
     switch (llvl) {
     case PJ_LOG_ERROR :
-        LogOnCError((char *)emsg);
-    default           :
     case PJ_LOG_DEBUG :
     case PJ_LOG_TRACE :
+        logOnC((uintptr_t)udata, llvl, (char *)emsg);
+        return;
+    default           :
     case PJ_LOG_NONE  :
         return ;
     }
     return;
 }
 
+void setLogFunc ( PJ_CONTEXT *ctx, uintptr_t id ) {
+    proj_log_func(ctx, (void *)id, logFuncToGo);
+}
+
+const char *fileFinderToGo ( PJ_CONTEXT *ctx, const char *name, void *udata ) {
+    (void)ctx;
+    return findFileOnC((uintptr_t)udata, (char *)name);
+}
+
+void setFileFinder ( PJ_CONTEXT *ctx, uintptr_t id ) {
+    if (id == 0) {
+        proj_context_set_file_finder(ctx, NULL, NULL);
+        return;
+    }
+    proj_context_set_file_finder(ctx, fileFinderToGo, (void *)id);
+}
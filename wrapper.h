@@ -4,8 +4,10 @@
 #include <stdlib.h>
 #include <stddef.h>  /* size_t */
 #include <string.h>
+#include <stdint.h>  /* uintptr_t */
 
 #include "proj.h"
+#include "proj_experimental.h"
 
 #ifdef __cplusplus
 extern "C" {
@@ -20,7 +22,20 @@ void PROJ_DLL destroyStringArray ( char ***t );
 double PROJ_DLL wrapper_proj_dmstor ( const char *dms );
 int PROJ_DLL nbUnitsFromPROJ ( void );
 PJ_UNITS PROJ_DLL *getUnitFromPROJ ( int i );
+int PROJ_DLL nbAngularUnitsFromPROJ ( void );
+PJ_UNITS PROJ_DLL *getAngularUnitFromPROJ ( int i );
+int PROJ_DLL nbEllipsoidsFromPROJ ( void );
+PJ_ELLPS PROJ_DLL *getEllipsoidFromPROJ ( int i );
+int PROJ_DLL nbPrimeMeridiansFromPROJ ( void );
+PJ_PRIME_MERIDIANS PROJ_DLL *getPrimeMeridianFromPROJ ( int i );
+int PROJ_DLL nbOperationsFromPROJ ( void );
+PJ_OPERATIONS PROJ_DLL *getOperationFromPROJ ( int i );
+const char PROJ_DLL *getOperationDescriptionFromPROJ ( PJ_OPERATIONS *op );
 void logFuncToGo ( void *udata, int llvl, const char *emsg );
+void setLogFunc ( PJ_CONTEXT *ctx, uintptr_t id );
+PROJ_CRS_INFO PROJ_DLL *getCRSInfoFromPROJ ( PROJ_CRS_INFO **l, int i );
+const char *fileFinderToGo ( PJ_CONTEXT *ctx, const char *name, void *udata );
+void setFileFinder ( PJ_CONTEXT *ctx, uintptr_t id );
 #ifdef __cplusplus
 }
 #endif